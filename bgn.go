@@ -9,10 +9,23 @@ import (
 )
 
 var (
-	actionToNotation = map[string]string{ActionPlaceTile: "p", bg.ActionSetWinners: "w"}
+	actionToNotation = map[string]string{ActionRotateTileClockwise: "r", ActionPlaceTile: "p", bg.ActionSetWinners: "w"}
 	notationToAction = reverseMap(actionToNotation)
 )
 
+func (p *RotateTileActionDetails) encodeBGN() []string {
+	return []string{p.Tile}
+}
+
+func decodeRotateTileActionDetailsBGN(notation []string) (*RotateTileActionDetails, error) {
+	if len(notation) != 1 {
+		return nil, errDecoding(fmt.Errorf("invalid rotate tile notation"))
+	}
+	return &RotateTileActionDetails{
+		Tile: notation[0],
+	}, nil
+}
+
 func (p *PlaceTileActionDetails) encodeBGN() []string {
 	return []string{strconv.Itoa(p.Row), strconv.Itoa(p.Column), p.Tile}
 }
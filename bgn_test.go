@@ -2,8 +2,11 @@ package go_indigo
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
+	bg "github.com/quibbble/go-boardgame"
 	"github.com/quibbble/go-boardgame/pkg/bgn"
 )
 
@@ -27,3 +30,262 @@ func Test_(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func Test_RotationsRoundTrip(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue", "green"},
+		MoreOptions: IndigoMoreOptions{Seed: 21},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 12; i++ {
+		for r, team := range game.state.teams {
+			for j := 0; j <= (i+r)%3; j++ {
+				hand := game.state.hands[team].GetItems()
+				if err := game.Do(&bg.BoardGameAction{
+					Team:        team,
+					ActionType:  ActionRotateTileClockwise,
+					MoreDetails: RotateTileActionDetails{Tile: hand[j%len(hand)].Paths},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		playPlaceTile(t, game)
+	}
+
+	builder := Builder{}
+	parsed, err := bgn.Parse(game.GetBGN().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := builder.Load(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dump(t, loaded.(*Indigo)) != dump(t, game) {
+		t.Fatal("loaded game does not match the original game")
+	}
+}
+
+func Test_OptionsRoundTrip(t *testing.T) {
+	tests := []IndigoMoreOptions{
+		{Seed: 1, Variant: VariantClassic},
+		{Seed: 2, Variant: VariantLargeHands},
+		{Seed: 3, Variant: VariantClassic, RoundsUntilEnd: 5},
+		{Seed: 4, Variant: VariantLargeHands, RoundsUntilEnd: 7},
+	}
+	for _, options := range tests {
+		for _, teams := range [][]string{{"red", "blue"}, {"red", "blue", "green", "yellow"}} {
+			game, err := NewIndigo(&bg.BoardGameOptions{Teams: teams, MoreOptions: options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for len(game.state.winners) == 0 {
+				playPlaceTile(t, game)
+
+				builder := Builder{}
+				parsed, err := bgn.Parse(game.GetBGN().String())
+				if err != nil {
+					t.Fatal(err)
+				}
+				loaded, err := builder.Load(parsed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if dump(t, loaded.(*Indigo)) != dump(t, game) {
+					t.Fatalf("loaded %s game with %d teams does not match the original game", options.Variant, len(teams))
+				}
+			}
+		}
+	}
+}
+
+func Test_PlayerBGN(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 1234567, RoundsUntilEnd: 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := game.CommitSeed(); err != nil {
+		t.Fatal(err)
+	}
+	builder := Builder{}
+	load := func(game *bgn.Game) (*Indigo, error) {
+		parsed, err := bgn.Parse(game.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := builder.Load(parsed)
+		if err != nil {
+			return nil, err
+		}
+		return loaded.(*Indigo), nil
+	}
+
+	for len(game.state.winners) == 0 {
+		hand := game.state.hands[game.state.turn].GetItems()
+		if err := game.Do(&bg.BoardGameAction{
+			Team:        game.state.turn,
+			ActionType:  ActionRotateTileClockwise,
+			MoreDetails: RotateTileActionDetails{Tile: hand[0].Paths},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		playPlaceTile(t, game)
+		if len(game.state.winners) > 0 {
+			break
+		}
+
+		player, err := game.GetPlayerBGN()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := player.Tags["Seed"]; ok || strings.Contains(player.String(), "1234567") {
+			t.Fatalf("seed revealed while the game is running:\n%s", player.String())
+		}
+		if _, ok := player.Tags["SeedSalt"]; ok {
+			t.Fatal("salt revealed while the game is running")
+		}
+		for _, action := range player.Actions {
+			if action.ActionKey == 'r' {
+				t.Fatal("rotation revealed while the game is running")
+			}
+		}
+		if _, err := load(player); err == nil {
+			t.Fatal("loaded a game whose seed is not revealed")
+		}
+
+		// saving and loading the full BGN keeps the commitment
+		full, err := load(game.GetBGN())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again, _ := full.GetPlayerBGN(); again.Tags["SeedCommitment"] != player.Tags["SeedCommitment"] {
+			t.Fatal("commitment changed after loading the game")
+		}
+	}
+
+	player, err := game.GetPlayerBGN()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if player.Tags["Seed"] != "1234567" || len(player.Actions) != len(game.actions) {
+		t.Fatalf("seed and rotations not revealed once the game is over:\n%s", player.String())
+	}
+	loaded, err := load(player)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dump(t, loaded) != dump(t, game) {
+		t.Fatal("loaded game does not match the original game")
+	}
+
+	player.Tags["Seed"] = "1234568"
+	if _, err := load(player); err == nil {
+		t.Fatal("loaded a game whose seed does not match its commitment")
+	}
+}
+
+func Test_ReproducibleBGN(t *testing.T) {
+	options := &bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 42},
+	}
+	games := make([]*Indigo, 0)
+	for i := 0; i < 2; i++ {
+		game, err := NewIndigo(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		playPlaceTile(t, game)
+		games = append(games, game)
+	}
+	first, _ := games[0].MarshalBinary()
+	second, _ := games[1].MarshalBinary()
+	if string(first) != string(second) {
+		t.Fatal("games created from the same options are not identical")
+	}
+	if _, ok := games[0].GetBGN().Tags["SeedSalt"]; ok {
+		t.Fatal("salt created before the seed was committed to")
+	}
+	if _, err := games[0].GetPlayerBGN(); err == nil {
+		t.Fatal("player bgn given before the seed was committed to")
+	}
+	loaded, err := (&Builder{}).Load(games[0].GetBGN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := loaded.(*Indigo).MarshalBinary(); string(again) != string(first) {
+		t.Fatal("loading the bgn does not give an identical game")
+	}
+
+	// the salt is made once and then kept so the commitment never changes
+	if err := games[0].CommitSeed(); err != nil {
+		t.Fatal(err)
+	}
+	player, err := games[0].GetPlayerBGN()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := games[0].CommitSeed(); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := games[0].GetPlayerBGN(); again.Tags["SeedCommitment"] != player.Tags["SeedCommitment"] {
+		t.Fatal("commitment changed between calls")
+	}
+	if games[0].GetBGN().Tags["SeedSalt"] == "" {
+		t.Fatal("salt not kept in the bgn once committed to")
+	}
+}
+
+func Test_PlayerBGNConcurrent(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue", "green"},
+		MoreOptions: IndigoMoreOptions{Seed: 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		playPlaceTile(t, game)
+	}
+
+	// run with -race to check that getting player bgn only reads the game whether or not the seed was committed to
+	playerBGN := func() []string {
+		var wg sync.WaitGroup
+		commitments := make([]string, 8)
+		for idx := range commitments {
+			wg.Add(1)
+			go func(idx int) {
+				defer wg.Done()
+				if player, err := game.GetPlayerBGN(); err == nil {
+					commitments[idx] = player.Tags["SeedCommitment"]
+				}
+			}(idx)
+		}
+		wg.Wait()
+		return commitments
+	}
+	for idx, commitment := range playerBGN() {
+		if commitment != "" {
+			t.Fatalf("call %d gave commitment %q before the seed was committed to", idx, commitment)
+		}
+	}
+	if game.options.Salt != "" {
+		t.Fatal("getting player bgn created a salt")
+	}
+
+	if err := game.CommitSeed(); err != nil {
+		t.Fatal(err)
+	}
+	expected := seedCommitment(game.options.Seed, game.options.Salt)
+	for idx, commitment := range playerBGN() {
+		if commitment != expected {
+			t.Fatalf("call %d gave commitment %q but expected %q", idx, commitment, expected)
+		}
+	}
+}
@@ -0,0 +1,475 @@
+package go_indigo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/mitchellh/mapstructure"
+	bg "github.com/quibbble/go-boardgame"
+	cl "github.com/quibbble/go-boardgame/pkg/collection"
+)
+
+const (
+	binaryVersion = 5
+
+	// cell values used in the binary encoding besides 1 + tile id
+	emptyCell    = 0
+	treasureCell = 255
+)
+
+var (
+	// colors are the gem colors in the order used by the binary encoding
+	colors = []string{Amber, Emerald, Sapphire}
+
+	// actionTypes are the action types in the order used by the binary encoding
+	actionTypes = []string{ActionRotateTileClockwise, ActionPlaceTile, bg.ActionSetWinners}
+)
+
+// MarshalBinary encodes the game compactly i.e. the options, board tiles, gems, deck, hands, points, turn, tiles placed and actions
+// NOTE - the placed tile results are not encoded so a decoded game only has the results of tiles placed after decoding
+func (i *Indigo) MarshalBinary() ([]byte, error) {
+	s := i.state
+	buf := []byte{binaryVersion}
+	buf = binary.AppendUvarint(buf, uint64(len(s.teams)))
+	for _, team := range s.teams {
+		buf = binary.AppendUvarint(buf, uint64(len(team)))
+		buf = append(buf, team...)
+	}
+	buf = append(buf, byte(indexOf(variants, s.variant)))
+	buf = binary.AppendVarint(buf, i.options.Seed)
+	buf = binary.AppendUvarint(buf, uint64(len(i.options.Salt)))
+	buf = append(buf, i.options.Salt...)
+	buf = binary.AppendUvarint(buf, uint64(s.roundsUntilEnd))
+	buf = binary.AppendUvarint(buf, uint64(s.round))
+	buf = binary.AppendUvarint(buf, uint64(s.placed))
+	buf = append(buf, byte(indexOf(s.teams, s.turn)))
+	buf = binary.AppendUvarint(buf, uint64(len(s.winners)))
+	for _, winner := range s.winners {
+		buf = append(buf, byte(indexOf(s.teams, winner)))
+	}
+	for cell := range s.board.tiles {
+		switch t := &s.board.tiles[cell]; {
+		case t.Paths == "":
+			buf = append(buf, emptyCell)
+		case t.Treasure:
+			buf = append(buf, treasureCell)
+		default:
+			id := t.id()
+			if id < 0 {
+				return nil, fmt.Errorf("cannot encode tile %s", t.Paths)
+			}
+			buf = append(buf, byte(1+id))
+		}
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(s.board.gems)))
+	for _, gem := range s.board.gems {
+		status := 0
+		if gem.gateway >= 0 {
+			status = 2 + gem.gateway
+		} else if gem.collided {
+			status = 1
+		}
+		buf = append(buf, byte(indexOf(colors, gem.Color)), byte(status))
+		buf = binary.AppendUvarint(buf, uint64(slotIndex(gem.cell, gem.edge)))
+		buf = binary.AppendUvarint(buf, uint64(gem.Ply))
+	}
+	tiles := func(items []tile) error {
+		buf = binary.AppendUvarint(buf, uint64(len(items)))
+		for _, t := range items {
+			id := t.id()
+			if id < 0 {
+				return fmt.Errorf("cannot encode tile %s", t.Paths)
+			}
+			buf = append(buf, byte(id))
+		}
+		return nil
+	}
+	if err := tiles(s.deck.GetItems()); err != nil {
+		return nil, err
+	}
+	for _, team := range s.teams {
+		if err := tiles(s.hands[team].GetItems()); err != nil {
+			return nil, err
+		}
+	}
+	for _, team := range s.teams {
+		buf = binary.AppendUvarint(buf, uint64(s.points[team]))
+		buf = binary.AppendUvarint(buf, uint64(s.gemsCount[team]))
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(i.actions)))
+	for _, action := range i.actions {
+		var err error
+		if buf, err = appendAction(buf, s.teams, action); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// appendAction encodes the action type, the team and the details of action
+func appendAction(buf []byte, teams []string, action *bg.BoardGameAction) ([]byte, error) {
+	actionType, team := indexOf(actionTypes, action.ActionType), indexOf(teams, action.Team)
+	if actionType < 0 || team < 0 {
+		return nil, fmt.Errorf("cannot encode %s action by %s", action.ActionType, action.Team)
+	}
+	buf = append(buf, byte(actionType), byte(team))
+	switch action.ActionType {
+	case ActionRotateTileClockwise:
+		var details RotateTileActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return nil, err
+		}
+		return appendPaths(buf, details.Tile)
+	case ActionPlaceTile:
+		var details PlaceTileActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return nil, err
+		}
+		if details.Row < 0 || details.Row >= rows || details.Column < 0 || details.Column >= rowLength(details.Row) {
+			return nil, fmt.Errorf("cannot encode placement at (%d, %d)", details.Row, details.Column)
+		}
+		buf = append(buf, byte(details.Row), byte(details.Column))
+		return appendPaths(buf, details.Tile)
+	default:
+		var details bg.SetWinnersActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return nil, err
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(details.Winners)))
+		for _, winner := range details.Winners {
+			idx := indexOf(teams, winner)
+			if idx < 0 {
+				return nil, fmt.Errorf("cannot encode winner %s", winner)
+			}
+			buf = append(buf, byte(idx))
+		}
+		return buf, nil
+	}
+}
+
+// appendPaths encodes the edges of the paths in the order they were written as a base 6 number
+// NOTE - tile ids are not used as tiles connecting the same edges share an id but can be written differently
+func appendPaths(buf []byte, paths string) ([]byte, error) {
+	if _, err := newTile(paths); err != nil {
+		return nil, err
+	}
+	value := 0
+	for idx := len(paths) - 1; idx >= 0; idx-- {
+		value = value*6 + edgeIndex[paths[idx]]
+	}
+	return binary.AppendUvarint(buf, uint64(value)), nil
+}
+
+// UnmarshalBinary decodes a game encoded with MarshalBinary
+// NOTE - the decoded game has every action so its BGN replays to the same position but it cannot undo earlier actions
+func (i *Indigo) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	version, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if version != binaryVersion {
+		return fmt.Errorf("unsupported binary version %d", version)
+	}
+	numTeams, err := readInt(r, maxTeams)
+	if err != nil {
+		return err
+	}
+	if numTeams < minTeams {
+		return fmt.Errorf("encoded game has %d teams", numTeams)
+	}
+	teams := make([]string, 0, numTeams)
+	for t := 0; t < numTeams; t++ {
+		length, err := readInt(r, r.Len())
+		if err != nil {
+			return err
+		}
+		team := make([]byte, length)
+		if _, err := io.ReadFull(r, team); err != nil {
+			return err
+		}
+		teams = append(teams, string(team))
+	}
+	variant, err := readInt(r, len(variants)-1)
+	if err != nil {
+		return err
+	}
+	seed, err := binary.ReadVarint(r)
+	if err != nil {
+		return err
+	}
+	saltLength, err := readInt(r, r.Len())
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return err
+	}
+	roundsUntilEnd, err := readInt(r, 1<<31-1)
+	if err != nil {
+		return err
+	}
+	round, err := readInt(r, 1<<31-1)
+	if err != nil {
+		return err
+	}
+	placed, err := readInt(r, numCells)
+	if err != nil {
+		return err
+	}
+	turn, err := readByte(r, numTeams-1)
+	if err != nil {
+		return err
+	}
+	numWinners, err := readInt(r, numTeams)
+	if err != nil {
+		return err
+	}
+	winners := make([]string, 0, numWinners)
+	for w := 0; w < numWinners; w++ {
+		winner, err := readByte(r, numTeams-1)
+		if err != nil {
+			return err
+		}
+		winners = append(winners, teams[winner])
+	}
+
+	board := newBoard(teams)
+	for cell := range board.tiles {
+		existing := &board.tiles[cell]
+		value, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case value == treasureCell:
+			if !existing.Treasure {
+				return fmt.Errorf("no treasure tile at (%d, %d)", cellRows[cell], cellColumns[cell])
+			}
+		case existing.Treasure:
+			return fmt.Errorf("missing treasure tile at (%d, %d)", cellRows[cell], cellColumns[cell])
+		case value != emptyCell:
+			t, err := tileFromID(int(value) - 1)
+			if err != nil {
+				return err
+			}
+			board.setTile(cell, t)
+		}
+	}
+	numGems, err := readInt(r, len(board.gems))
+	if err != nil {
+		return err
+	}
+	if numGems != len(board.gems) {
+		return fmt.Errorf("encoded game has %d gems", numGems)
+	}
+	for g := range board.gems {
+		color, err := readByte(r, len(colors)-1)
+		if err != nil {
+			return err
+		}
+		status, err := readByte(r, 1+numGateways)
+		if err != nil {
+			return err
+		}
+		location, err := readInt(r, numCells*7-1)
+		if err != nil {
+			return err
+		}
+		ply, err := readInt(r, placed)
+		if err != nil {
+			return err
+		}
+		gateway := -1
+		if status > 1 {
+			gateway = status - 2
+		}
+		board.gems[g] = gem{
+			ID:       g,
+			Color:    colors[color],
+			Ply:      ply,
+			cell:     location / 7,
+			edge:     location % 7,
+			collided: status == 1,
+			gateway:  gateway,
+		}
+	}
+
+	tiles := func(c *cl.Collection[tile]) error {
+		size, err := readInt(r, r.Len())
+		if err != nil {
+			return err
+		}
+		for idx := 0; idx < size; idx++ {
+			id, err := readByte(r, numTileIDs-1)
+			if err != nil {
+				return err
+			}
+			t, _ := tileFromID(id)
+			c.Add(*t)
+		}
+		return nil
+	}
+	deck := cl.NewCollection[tile](seed)
+	if err := tiles(deck); err != nil {
+		return err
+	}
+	hands := make(map[string]*cl.Collection[tile])
+	for _, team := range teams {
+		hands[team] = cl.NewCollection[tile](0)
+		if err := tiles(hands[team]); err != nil {
+			return err
+		}
+	}
+	points := make(map[string]int)
+	gemsCount := make(map[string]int)
+	for _, team := range teams {
+		if points[team], err = readInt(r, 1<<31-1); err != nil {
+			return err
+		}
+		if gemsCount[team], err = readInt(r, 1<<31-1); err != nil {
+			return err
+		}
+	}
+	numActions, err := readInt(r, r.Len())
+	if err != nil {
+		return err
+	}
+	actions := make([]*bg.BoardGameAction, 0, numActions)
+	for a := 0; a < numActions; a++ {
+		action, err := readAction(r, teams)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("%d unexpected bytes after encoded game", r.Len())
+	}
+
+	i.state = &state{
+		turn:           teams[turn],
+		teams:          teams,
+		winners:        winners,
+		board:          board,
+		deck:           deck,
+		hands:          hands,
+		variant:        variants[variant],
+		points:         points,
+		gemsCount:      gemsCount,
+		round:          round,
+		roundsUntilEnd: roundsUntilEnd,
+		placed:         placed,
+		results:        make([]*PlaceTileResult, 0),
+	}
+	i.state.hash = i.state.zobrist()
+	i.actions = actions
+	i.options = &IndigoMoreOptions{
+		Seed:           seed,
+		Variant:        variants[variant],
+		RoundsUntilEnd: roundsUntilEnd,
+		Salt:           string(salt),
+	}
+	i.undos = make([]*checkpoint, 0)
+	i.redos = make([]*checkpoint, 0)
+	i.observers = nil
+	return nil
+}
+
+// readAction reads an action encoded with appendAction
+func readAction(r *bytes.Reader, teams []string) (*bg.BoardGameAction, error) {
+	actionType, err := readByte(r, len(actionTypes)-1)
+	if err != nil {
+		return nil, err
+	}
+	team, err := readByte(r, len(teams)-1)
+	if err != nil {
+		return nil, err
+	}
+	action := &bg.BoardGameAction{
+		Team:       teams[team],
+		ActionType: actionTypes[actionType],
+	}
+	switch action.ActionType {
+	case ActionRotateTileClockwise:
+		paths, err := readPaths(r)
+		if err != nil {
+			return nil, err
+		}
+		action.MoreDetails = RotateTileActionDetails{Tile: paths}
+	case ActionPlaceTile:
+		row, err := readByte(r, rows-1)
+		if err != nil {
+			return nil, err
+		}
+		col, err := readByte(r, rowLength(row)-1)
+		if err != nil {
+			return nil, err
+		}
+		paths, err := readPaths(r)
+		if err != nil {
+			return nil, err
+		}
+		action.MoreDetails = PlaceTileActionDetails{Tile: paths, Row: row, Column: col}
+	default:
+		numWinners, err := readInt(r, len(teams))
+		if err != nil {
+			return nil, err
+		}
+		winners := make([]string, 0, numWinners)
+		for w := 0; w < numWinners; w++ {
+			winner, err := readByte(r, len(teams)-1)
+			if err != nil {
+				return nil, err
+			}
+			winners = append(winners, teams[winner])
+		}
+		action.MoreDetails = bg.SetWinnersActionDetails{Winners: winners}
+	}
+	return action, nil
+}
+
+// readPaths reads tile paths encoded with appendPaths
+func readPaths(r *bytes.Reader) (string, error) {
+	value, err := readInt(r, 6*6*6*6*6*6-1)
+	if err != nil {
+		return "", err
+	}
+	var paths [6]byte
+	for idx := range paths {
+		paths[idx] = allEdges[value%6][0]
+		value /= 6
+	}
+	t, err := newTile(string(paths[:]))
+	if err != nil {
+		return "", err
+	}
+	return t.Paths, nil
+}
+
+// readInt reads a uvarint that must be at most max
+func readInt(r *bytes.Reader, max int) (int, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if v > uint64(max) {
+		return 0, fmt.Errorf("encoded value %d larger than %d", v, max)
+	}
+	return int(v), nil
+}
+
+// readByte reads a byte that must be at most max
+func readByte(r *bytes.Reader, max int) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if int(b) > max {
+		return 0, fmt.Errorf("encoded value %d larger than %d", b, max)
+	}
+	return int(b), nil
+}
@@ -0,0 +1,176 @@
+package go_indigo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+func Test_BinaryRoundTrip(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue", "green", "yellow"},
+		MoreOptions: IndigoMoreOptions{Seed: 31, Variant: VariantLargeHands},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(game.state.winners) == 0 {
+		playPlaceTile(t, game)
+
+		raw, err := game.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Indigo
+		if err := decoded.UnmarshalBinary(raw); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Hash() != game.Hash() {
+			t.Fatal("decoded game hash does not match the original game")
+		}
+		if !reflect.DeepEqual(decoded.state.board, game.state.board) {
+			t.Fatal("decoded board does not match the original board")
+		}
+		for _, team := range game.state.teams {
+			if !reflect.DeepEqual(decoded.state.hands[team].GetItems(), game.state.hands[team].GetItems()) ||
+				decoded.state.points[team] != game.state.points[team] {
+				t.Fatalf("decoded hand or points of %s do not match the original game", team)
+			}
+		}
+		again, _ := decoded.MarshalBinary()
+		if string(again) != string(raw) {
+			t.Fatal("encoding the decoded game does not match the original encoding")
+		}
+	}
+}
+
+func Test_DecodedPly(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 12},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		playPlaceTile(t, game)
+	}
+	raw, err := game.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromBinary Indigo
+	if err := fromBinary.UnmarshalBinary(raw); err != nil {
+		t.Fatal(err)
+	}
+	raw, err = json.Marshal(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON Indigo
+	if err := json.Unmarshal(raw, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	// keep playing the same moves in every game so gems leave play at the same ply
+	for len(game.state.winners) == 0 {
+		move := game.state.legalMoves()[0]
+		for _, g := range []*Indigo{game, &fromBinary, &fromJSON} {
+			if err := g.Do(move); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for idx, gem := range game.state.board.gems {
+		if fromBinary.state.board.gems[idx].Ply != gem.Ply || fromJSON.state.board.gems[idx].Ply != gem.Ply {
+			t.Errorf("gem %d left play at ply %d but decoded games have ply %d and %d", idx, gem.Ply,
+				fromBinary.state.board.gems[idx].Ply, fromJSON.state.board.gems[idx].Ply)
+		}
+	}
+}
+
+func Test_BinaryBGN(t *testing.T) {
+	teams := []string{"red", "blue", "green"}
+	game, err := NewIndigo(&bg.BoardGameOptions{Teams: teams, MoreOptions: IndigoMoreOptions{Seed: 5, Variant: VariantLargeHands}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 12; i++ {
+		hand := game.state.hands[game.state.turn].GetItems()
+		if err := game.Do(&bg.BoardGameAction{
+			Team:        game.state.turn,
+			ActionType:  ActionRotateTileClockwise,
+			MoreDetails: RotateTileActionDetails{Tile: hand[i%len(hand)].Paths},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		playPlaceTile(t, game)
+	}
+	if err := game.Do(&bg.BoardGameAction{
+		Team:        teams[0],
+		ActionType:  bg.ActionSetWinners,
+		MoreDetails: bg.SetWinnersActionDetails{Winners: teams[1:]},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := game.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Indigo
+	if err := decoded.UnmarshalBinary(raw); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.GetBGN().Actions, game.GetBGN().Actions) {
+		t.Fatal("decoded game BGN does not have the actions of the original game")
+	}
+
+	// the BGN of the decoded game must rebuild the saved position and not the opening one
+	builder := Builder{}
+	loaded, err := builder.Load(decoded.GetBGN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := loaded.(*Indigo).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(raw) {
+		t.Fatal("loading the BGN of the decoded game does not give the original game")
+	}
+}
+
+func Test_Hash(t *testing.T) {
+	collisions := 0
+	for seed := int64(0); seed < 20; seed++ {
+		game, err := NewIndigo(&bg.BoardGameOptions{
+			Teams:       []string{"red", "blue", "green", "yellow"}[:2+seed%3],
+			MoreOptions: IndigoMoreOptions{Seed: seed},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes := map[uint64]bool{game.Hash(): true}
+		for len(game.state.winners) == 0 {
+			playPlaceTile(t, game)
+			collisions += len(game.state.results[len(game.state.results)-1].Collisions)
+			if game.Hash() != game.state.zobrist() {
+				t.Fatalf("incremental hash does not match the hash computed from scratch with seed %d", seed)
+			}
+			if hashes[game.Hash()] {
+				t.Fatal("different positions have the same hash")
+			}
+			hashes[game.Hash()] = true
+		}
+		_ = game.Undo(3)
+		if game.Hash() != game.state.zobrist() {
+			t.Fatal("hash not restored by undo")
+		}
+	}
+	if collisions == 0 {
+		t.Fatal("expected some games to update the hash for collided gems")
+	}
+}
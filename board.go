@@ -1,6 +1,7 @@
 package go_indigo
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -11,171 +12,292 @@ const (
 	maxColumns = 9
 )
 
+const (
+	numCells    = 61 // the number of tiles on the board
+	numGateways = 6
+	numGems     = 12
+
+	specialEdge = 6 // the index of the Special edge after the six edges of a tile
+	noEdge      = -1
+)
+
 var (
-	edgeToEdge = map[string]string{A: D, B: E, C: F, D: A, E: B, F: C}
+	// allEdges are the edges in clockwise order so the opposite of allEdges[i] is allEdges[(i+3)%6]
+	allEdges = [6]string{A, B, C, D, E, F}
+
+	// edgeNames are the edge of every edge index with Special last
+	edgeNames = [7]string{A, B, C, D, E, F, Special}
+
+	// edgeIndex is the index in edgeNames of each edge character
+	edgeIndex = [256]int{'A': 0, 'B': 1, 'C': 2, 'D': 3, 'E': 4, 'F': 5, 'S': specialEdge}
+
+	// row and column offsets of the adjacent tile on each edge above and below the middle row
+	edgeToRowColTop = [6][2]int{{-1, -1}, {-1, 0}, {0, 1}, {1, 1}, {1, 0}, {0, -1}}
+	edgeToRowColBot = [6][2]int{{-1, 0}, {-1, 1}, {0, 1}, {1, 0}, {1, -1}, {0, -1}}
+
+	// rowOffsets are the index of the first cell in each row
+	rowOffsets = func() [rows]int {
+		var offsets [rows]int
+		for r := 1; r < rows; r++ {
+			offsets[r] = offsets[r-1] + rowLength(r-1)
+		}
+		return offsets
+	}()
+
+	// cellRows and cellColumns are the row and column of every cell
+	cellRows, cellColumns [numCells]int
+
+	// neighbors are the cell adjacent to each edge of every cell or -1 if off the board
+	neighbors [numCells][6]int
+
+	// gatewaysByCell are the index of the gateway touching each edge of every cell or -1 if none
+	gatewaysByCell [numCells][7]int8
+
+	// cellGateways are the index of the gateway touching every cell or -1 if none
+	cellGateways [numCells]int8
 )
 
+func init() {
+	for r := 0; r < rows; r++ {
+		for c := 0; c < rowLength(r); c++ {
+			cellRows[cellIndex(r, c)] = r
+			cellColumns[cellIndex(r, c)] = c
+		}
+	}
+	b := &board{}
+	for cell := 0; cell < numCells; cell++ {
+		for e, edge := range allEdges {
+			adjRow, adjCol, _ := b.getAdjacent(cellRows[cell], cellColumns[cell], edge)
+			neighbors[cell][e] = -1
+			if adjRow >= 0 && adjRow < rows && adjCol >= 0 && adjCol < rowLength(adjRow) {
+				neighbors[cell][e] = cellIndex(adjRow, adjCol)
+			}
+		}
+		cellGateways[cell] = -1
+		for e := range gatewaysByCell[cell] {
+			gatewaysByCell[cell][e] = -1
+		}
+	}
+	for idx, edges := range gatewayEdges {
+		for _, location := range initGateways[edges] {
+			cellGateways[cellIndex(location[0], location[1])] = int8(idx)
+			for _, edge := range edges {
+				gatewaysByCell[cellIndex(location[0], location[1])][edgeIndex[edge]] = int8(idx)
+			}
+		}
+	}
+}
+
+// rowLength returns the number of tiles in row
+func rowLength(row int) int {
+	if row <= rows/2 {
+		return minColumns + row
+	}
+	return minColumns + rows - 1 - row
+}
+
+// cellIndex numbers the tiles on the board row by row
+func cellIndex(row, col int) int {
+	return rowOffsets[row] + col
+}
+
+// slotIndex numbers every edge of every cell with the Special edge last
+func slotIndex(cell, edge int) int {
+	return cell*7 + edge
+}
+
+// board holds everything in fixed size arrays indexed by cell, gateway and gem so copying the struct copies the board
 type board struct {
-	Tiles    [][]*tile // 0,0 is upper left most tile
-	Gateways []*gateway
-	Gems     []*gem
+	tiles    [numCells]tile    // the tile in every cell as it was written or the zero tile if the cell is empty
+	exits    [numCells][6]int8 // the edge a gem entering each edge of the tile in every cell leaves by or noEdge
+	gateways [numGateways]gateway
+	gems     [numGems]gem
 }
 
 func newBoard(teams []string) *board {
-	var b = make([][]*tile, rows)
-	columns := minColumns
-	for i := 0; i < rows; i++ {
-		b[i] = make([]*tile, columns)
-		if i < 4 {
-			columns++
-		} else {
-			columns--
-		}
-	}
+	b := &board{}
 
 	// place treasure tiles
 	for edges, location := range initTreasureTiles {
-		b[location[0]][location[1]] = newTreasureTile(edges)
+		b.setTile(cellIndex(location[0], location[1]), newTreasureTile(edges))
 	}
 
 	// create gateways
-	gateways := make([]*gateway, 0)
-	for edges, teamsIdxs := range numTeamsToGatewayOwnership[len(teams)] {
+	for id, edges := range gatewayEdges {
 		owners := make([]string, 0)
-		for _, idx := range teamsIdxs {
+		for _, idx := range numTeamsToGatewayOwnership[len(teams)][edges] {
 			owners = append(owners, teams[idx])
 		}
-		gateways = append(gateways, newGateway(initGateways[edges], edges, owners...))
+		b.gateways[id] = *newGateway(id, initGateways[edges], edges, owners...)
 	}
 
 	// create gems
-	gems := make([]*gem, 0)
-	for _, gem := range initGems {
-		gems = append(gems, newGem(gem[0].(string), gem[1].(string), gem[2].(int), gem[3].(int)))
+	for idx, gem := range initGems {
+		b.gems[idx] = *newGem(idx, gem[0].(string), gem[1].(string), gem[2].(int), gem[3].(int))
 	}
 
-	return &board{
-		Tiles:    b,
-		Gems:     gems,
-		Gateways: gateways,
+	return b
+}
+
+// tileAt returns the tile at (row, col) or nil if the cell is empty
+func (b *board) tileAt(row, col int) *tile {
+	if t := &b.tiles[cellIndex(row, col)]; t.Paths != "" {
+		return t
+	}
+	return nil
+}
+
+// setTile puts a copy of t in cell and works out where gems entering each of its edges leave by the same way as GetDestination
+func (b *board) setTile(cell int, t *tile) {
+	b.tiles[cell] = *t
+	for e, edge := range allEdges {
+		switch idx := strings.IndexByte(t.Paths, edge[0]); {
+		case idx >= 0 && idx%2 == 0 && idx+1 < len(t.Paths):
+			b.exits[cell][e] = int8(edgeIndex[t.Paths[idx+1]])
+		case idx >= 0 && idx%2 == 1:
+			b.exits[cell][e] = int8(edgeIndex[t.Paths[idx-1]])
+		default:
+			b.exits[cell][e] = noEdge
+		}
 	}
 }
 
 func (b *board) place(tile *tile, row, col int) error {
-	if row < 0 || col < 0 || row >= rows || col >= len(b.Tiles[row]) {
+	if err := b.canPlace(tile, row, col); err != nil {
+		return err
+	}
+	b.setTile(cellIndex(row, col), tile)
+	return nil
+}
+
+// canPlace returns an error if tile cannot be placed at (row, col)
+func (b *board) canPlace(tile *tile, row, col int) error {
+	if row < 0 || col < 0 || row >= rows || col >= rowLength(row) {
 		return fmt.Errorf("index out of bounds")
 	}
-	if b.Tiles[row][col] != nil {
+	if b.tiles[cellIndex(row, col)].Paths != "" {
 		return fmt.Errorf("tile already exists at (%d, %d)", row, col)
 	}
 	if len(tile.Paths) != 6 {
 		return fmt.Errorf("invalid tile paths")
 	}
-	paths := []string{tile.Paths[0:2], tile.Paths[2:4], tile.Paths[4:6]}
-	for _, gateway := range b.Gateways {
-		for _, location := range gateway.Locations {
-			if row == location[0] && col == location[1] && contains(paths, gateway.Edges) {
-				return fmt.Errorf("cannot place a tile in a way that blocks a gateway")
-			}
+	// a path joining both edges of a gateway blocks it whichever order the path is written in
+	if idx := cellGateways[cellIndex(row, col)]; idx >= 0 {
+		gateway := gatewayEdges[idx]
+		if destination, _ := tile.GetDestination(gateway[0:1]); destination == gateway[1:2] {
+			return fmt.Errorf("cannot place a tile in a way that blocks a gateway")
 		}
 	}
-	b.Tiles[row][col] = tile
 	return nil
 }
 
-func (b *board) moveGems(placedRow, placedCol int) ([]*gem, error) {
+// moveGems moves every gem that has a tile in front of it recording each step, collision and gateway reached in result if not nil
+// NOTE only gems moved the first pass could be moved again so only those are returned
+func (b *board) moveGems(placedRow, placedCol int, result *PlaceTileResult) ([]*gem, error) {
+	// number of gems at every edge of every cell so collisions are found without checking every gem
+	var occupied [numCells * 7]int8
+	for idx := range b.gems {
+		if gem := &b.gems[idx]; gem.gateway < 0 {
+			occupied[slotIndex(gem.cell, gem.edge)]++
+		}
+	}
+	placed := -1
+	if placedRow >= 0 && placedCol >= 0 {
+		placed = cellIndex(placedRow, placedCol)
+	}
+	moved, err := b.moveGemsOnce(placed, result, &occupied)
+	if err != nil {
+		return nil, err
+	}
+	for again := len(moved) > 0; again; {
+		next, err := b.moveGemsOnce(-1, result, &occupied)
+		if err != nil {
+			return nil, err
+		}
+		again = len(next) > 0
+	}
+	return moved, nil
+}
+
+// moveGemsOnce moves every gem with a tile in front of it by one tile and returns those that moved
+func (b *board) moveGemsOnce(placed int, result *PlaceTileResult, occupied *[numCells * 7]int8) ([]*gem, error) {
 	moved := []*gem{}
 	centerGemMoved := false
 
 nextGem:
-	for _, gem := range b.Gems {
-		if gem.collided || gem.gateway != nil {
+	for idx := range b.gems {
+		gem := &b.gems[idx]
+		if gem.collided || gem.gateway >= 0 {
 			continue
 		}
 
-		var (
-			adjRow, adjCol int
-			adjEdge        string
-		)
+		adjCell, adjEdge := -1, noEdge
 
 		// case where tile placed adj to middle treasure tile and one gem must be moved
-		if gem.Edge == Special {
-			if !centerGemMoved && placedRow >= 0 && placedCol >= 0 {
-				edgeMap := map[string][2]int{
-					"A": {-1, -1},
-					"B": {-1, 0},
-					"C": {0, 1},
-					"D": {1, 0},
-					"E": {1, -1},
-					"F": {0, -1},
-				}
-				for edge, loc := range edgeMap {
-					if gem.Row+loc[0] == placedRow &&
-						gem.Column+loc[1] == placedCol {
-						adjRow = placedRow
-						adjCol = placedCol
-						adjEdge = edgeToEdge[edge]
-						centerGemMoved = true
-						break
-					}
-				}
-				if !centerGemMoved {
-					continue nextGem
+		if gem.edge == specialEdge {
+			if centerGemMoved || placed < 0 {
+				continue nextGem
+			}
+			for edge, neighbor := range neighbors[gem.cell] {
+				if neighbor == placed {
+					adjCell = placed
+					adjEdge = (edge + 3) % 6
+					centerGemMoved = true
+					break
 				}
-			} else {
+			}
+			if !centerGemMoved {
 				continue nextGem
 			}
-
 		}
 
 		// base case where gem has a adj tile and must be moved
-		if adjEdge == "" {
-			adjRow, adjCol, adjEdge = b.getAdjacent(gem.Row, gem.Column, gem.Edge)
-			if adjRow < 0 || adjRow >= len(b.Tiles) ||
-				adjCol < 0 || adjCol >= len(b.Tiles[adjRow]) ||
-				b.Tiles[adjRow][adjCol] == nil {
+		if adjEdge == noEdge {
+			neighbor := neighbors[gem.cell][gem.edge]
+			if neighbor < 0 || b.tiles[neighbor].Paths == "" {
 				continue nextGem
 			}
+			adjCell, adjEdge = neighbor, (gem.edge+3)%6
 		}
 
 		// check for collision
-		for _, g := range b.Gems {
-			if g.Row == adjRow && g.Column == adjCol && g.Edge == adjEdge {
-				gem.collided = true
-				g.collided = true
-				continue nextGem
+		if occupied[slotIndex(adjCell, adjEdge)] > 0 {
+			for gIdx := range b.gems {
+				if g := &b.gems[gIdx]; g.cell == adjCell && g.edge == adjEdge {
+					gem.collided = true
+					g.collided = true
+					if result != nil {
+						result.Collisions = append(result.Collisions, &GemCollision{
+							Gems:   [2]int{idx, gIdx},
+							Row:    cellRows[adjCell],
+							Column: cellColumns[adjCell],
+							Edge:   edgeNames[adjEdge],
+						})
+					}
+					continue nextGem
+				}
 			}
 		}
 
-		movedEdge, err := b.Tiles[adjRow][adjCol].GetDestination(adjEdge)
-		if err != nil {
+		movedEdge := int(b.exits[adjCell][adjEdge])
+		if movedEdge == noEdge {
+			_, err := b.tiles[adjCell].GetDestination(edgeNames[adjEdge])
 			return nil, err
 		}
 
-		gem.Row = adjRow
-		gem.Column = adjCol
-		gem.Edge = movedEdge
+		if result != nil {
+			result.step(idx, gem, cellRows[adjCell], cellColumns[adjCell], edgeNames[movedEdge])
+		}
+
+		occupied[slotIndex(gem.cell, gem.edge)]--
+		gem.cell = adjCell
+		gem.edge = movedEdge
+		occupied[slotIndex(gem.cell, gem.edge)]++
 
 		moved = append(moved, gem)
 
 		// check for gateway reached
-	nextGateway:
-		for _, gateway := range b.Gateways {
-			for _, loc := range gateway.Locations {
-				if loc[0] == gem.Row && loc[1] == gem.Column && strings.Contains(gateway.Edges, gem.Edge) {
-					gem.gateway = gateway
-					break nextGateway
-				}
-			}
-		}
-	}
-
-	if len(moved) > 0 {
-		// NOTE only gems moved the first iteration could be moved again so do not need to concat returned gems on future iterations
-		_, err := b.moveGems(-1, -1)
-		if err != nil {
-			return nil, err
-		}
+		gem.gateway = int(gatewaysByCell[gem.cell][gem.edge])
 	}
 
 	return moved, nil
@@ -183,31 +305,89 @@ nextGem:
 
 // getAdjacent returns the adjacent row, col, and edge
 func (b *board) getAdjacent(row, col int, edge string) (adjRow, adjCol int, adjEdge string) {
-	edgeToRowColTop := map[string][2]int{A: {-1, -1}, B: {-1, 0}, C: {0, 1}, D: {1, 1}, E: {1, 0}, F: {0, -1}}
-	edgeToRowColBot := map[string][2]int{A: {-1, 0}, B: {-1, 1}, C: {0, 1}, D: {1, 0}, E: {1, -1}, F: {0, -1}}
-	var edgeMap map[string][2]int
-	if row < rows/2 {
-		edgeMap = edgeToRowColTop
-	} else if row > rows/2 {
-		edgeMap = edgeToRowColBot
-	} else {
-		if strings.Contains("AB", edge) {
-			edgeMap = edgeToRowColTop
-		} else if strings.Contains("DE", edge) {
-			edgeMap = edgeToRowColBot
-		} else {
-			edgeMap = edgeToRowColTop
-		}
+	e := edgeIndex[edge[0]]
+	offsets := &edgeToRowColTop
+	if row > rows/2 || (row == rows/2 && (edge == D || edge == E)) {
+		offsets = &edgeToRowColBot
 	}
-	return row + edgeMap[edge][0], col + edgeMap[edge][1], edgeToEdge[edge]
+	return row + offsets[e][0], col + offsets[e][1], allEdges[(e+3)%6]
 }
 
 func (b *board) gemsInPlay() int {
 	count := 0
-	for _, gem := range b.Gems {
-		if !gem.collided && gem.gateway == nil {
+	for idx := range b.gems {
+		if !b.gems[idx].collided && b.gems[idx].gateway < 0 {
 			count++
 		}
 	}
 	return count
 }
+
+// clone returns a copy of the board
+// NOTE - the teams of each gateway are shared as they never change once the board is created
+func (b *board) clone() *board {
+	c := *b
+	return &c
+}
+
+// rows returns the tiles on the board row by row with nil for empty cells
+func (b *board) rows() [][]*tile {
+	tiles := make([][]*tile, rows)
+	for r := range tiles {
+		tiles[r] = make([]*tile, rowLength(r))
+		for c := range tiles[r] {
+			tiles[r][c] = b.tileAt(r, c)
+		}
+	}
+	return tiles
+}
+
+// gatewayList returns a pointer to every gateway in order of id
+func (b *board) gatewayList() []*gateway {
+	gateways := make([]*gateway, 0, numGateways)
+	for idx := range b.gateways {
+		gateways = append(gateways, &b.gateways[idx])
+	}
+	return gateways
+}
+
+// MarshalJSON writes the board as rows of tiles, the gateways and the gems with their location, status and the gateway they reached
+func (b *board) MarshalJSON() ([]byte, error) {
+	type gemFields struct {
+		ID          int
+		Color       string
+		Edge        string
+		Row, Column int
+		Ply         int
+		Status      string
+		Gateway     *gateway
+	}
+	gems := make([]gemFields, 0, numGems)
+	for idx := range b.gems {
+		gem := &b.gems[idx]
+		row, col, edge := gem.location()
+		var scoredAt *gateway
+		if gem.gateway >= 0 {
+			scoredAt = &b.gateways[gem.gateway]
+		}
+		gems = append(gems, gemFields{
+			ID:      gem.ID,
+			Color:   gem.Color,
+			Edge:    edge,
+			Row:     row,
+			Column:  col,
+			Ply:     gem.Ply,
+			Status:  gem.Status(),
+			Gateway: scoredAt,
+		})
+	}
+	return json.Marshal(struct {
+		Tiles    [][]*tile
+		Gateways []*gateway
+		Gems     []gemFields
+	}{
+		Tiles:    b.rows(),
+		Gateways: b.gatewayList(),
+		Gems:     gems,
+	})
+}
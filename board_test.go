@@ -0,0 +1,284 @@
+package go_indigo
+
+import (
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+func Test_GetAdjacent(t *testing.T) {
+	tests := []struct {
+		row, col       int
+		edge           string
+		adjRow, adjCol int
+		adjEdge        string
+	}{
+		// rows above the middle
+		{2, 3, A, 1, 2, D},
+		{2, 3, B, 1, 3, E},
+		{2, 3, C, 2, 4, F},
+		{2, 3, D, 3, 4, A},
+		{2, 3, E, 3, 3, B},
+		{2, 3, F, 2, 2, C},
+		// middle row goes up like the rows above and down like the rows below
+		{4, 4, A, 3, 3, D},
+		{4, 4, B, 3, 4, E},
+		{4, 4, C, 4, 5, F},
+		{4, 4, D, 5, 4, A},
+		{4, 4, E, 5, 3, B},
+		{4, 4, F, 4, 3, C},
+		// rows below the middle
+		{6, 2, A, 5, 2, D},
+		{6, 2, B, 5, 3, E},
+		{6, 2, C, 6, 3, F},
+		{6, 2, D, 7, 2, A},
+		{6, 2, E, 7, 1, B},
+		{6, 2, F, 6, 1, C},
+	}
+	b := newBoard([]string{"red", "blue"})
+	for _, test := range tests {
+		adjRow, adjCol, adjEdge := b.getAdjacent(test.row, test.col, test.edge)
+		if adjRow != test.adjRow || adjCol != test.adjCol || adjEdge != test.adjEdge {
+			t.Errorf("(%d, %d) %s is adjacent to (%d, %d) %s but expected (%d, %d) %s", test.row, test.col, test.edge,
+				adjRow, adjCol, adjEdge, test.adjRow, test.adjCol, test.adjEdge)
+		}
+	}
+
+	// every neighbor must be adjacent back to the cell it was found from
+	for row := 0; row < rows; row++ {
+		for col := 0; col < rowLength(row); col++ {
+			for _, edge := range allEdges {
+				adjRow, adjCol, adjEdge := b.getAdjacent(row, col, edge)
+				if adjRow < 0 || adjRow >= rows || adjCol < 0 || adjCol >= rowLength(adjRow) {
+					continue
+				}
+				if r, c, e := b.getAdjacent(adjRow, adjCol, adjEdge); r != row || c != col || e != edge {
+					t.Errorf("(%d, %d) %s is adjacent to (%d, %d) %s which is adjacent to (%d, %d) %s", row, col, edge,
+						adjRow, adjCol, adjEdge, r, c, e)
+				}
+			}
+		}
+	}
+}
+
+func Test_CanPlace(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    string
+		row, col int
+		blocked  bool
+	}{
+		{"empty cell", A + F + B + C + D + E, 2, 2, false},
+		{"treasure tile", A + F + B + C + D + E, 4, 4, true},
+		{"gateway cell without gateway path", A + D + B + F + C + E, 0, 2, false},
+		{"path joining gateway edges", F + E + A + B + C + D, 0, 2, true},
+		{"path joining gateway edges written backwards", B + A + C + D + E + F, 0, 2, true},
+		{"path joining edges of another gateway", A + F + B + C + D + E, 0, 2, false},
+		{"backwards path on the last gateway", A + F + B + C + D + E, 2, 0, true},
+		{"out of bounds", A + F + B + C + D + E, 0, 5, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := newBoard([]string{"red", "blue"}).canPlace(&tile{Paths: test.paths}, test.row, test.col)
+			if blocked := err != nil; blocked != test.blocked {
+				t.Errorf("placing %s at (%d, %d) blocked %t but expected %t: %v", test.paths, test.row, test.col, blocked, test.blocked, err)
+			}
+		})
+	}
+}
+
+func Test_MoveGems(t *testing.T) {
+	type placement struct {
+		paths    string
+		row, col int
+	}
+	type location struct {
+		row, col int
+		edge     string
+	}
+	tests := []struct {
+		name       string
+		placements []placement
+		expected   map[int]location
+		collided   map[int]bool
+		collisions [][2]int
+		scored     map[int]string
+	}{
+		{
+			name:       "center releases a single gem",
+			placements: []placement{{B + E + C + F + D + A, 3, 4}},
+			expected: map[int]location{
+				6: {3, 4, B}, 7: {4, 4, Special}, 8: {4, 4, Special},
+				9: {4, 4, Special}, 10: {4, 4, Special}, 11: {4, 4, Special},
+			},
+		},
+		{
+			name:       "center releases one gem per placement",
+			placements: []placement{{B + E + C + F + D + A, 3, 4}, {B + E + C + F + D + A, 5, 3}},
+			expected: map[int]location{
+				6: {3, 4, B}, 7: {5, 3, E}, 8: {4, 4, Special},
+			},
+		},
+		{
+			name:       "gem moves across several tiles",
+			placements: []placement{{B + E + C + F + D + A, 3, 4}, {A + F + B + C + D + E, 4, 5}},
+			expected:   map[int]location{6: {3, 4, B}, 7: {3, 4, A}},
+		},
+		{
+			name:       "corner gem stops facing an empty cell",
+			placements: []placement{{A + D + B + F + C + E, 1, 1}},
+			expected:   map[int]location{0: {1, 1, D}},
+		},
+		{
+			name: "gems meeting on the same edge collide",
+			placements: []placement{
+				{A + B + C + E + D + F, 3, 4}, {A + D + B + F + C + E, 4, 5}, {F + E + A + B + C + D, 3, 5},
+			},
+			expected:   map[int]location{6: {3, 5, E}, 7: {4, 5, B}},
+			collided:   map[int]bool{6: true, 7: true},
+			collisions: [][2]int{{7, 6}},
+		},
+		{
+			name:       "gem reaches a gateway",
+			placements: []placement{{A + F + B + C + D + E, 4, 7}, {A + B + C + E + D + F, 3, 7}},
+			expected:   map[int]location{2: {3, 7, C}},
+			scored:     map[int]string{2: B + C},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newBoard([]string{"red", "blue"})
+			result := &PlaceTileResult{}
+			for _, p := range test.placements {
+				if err := b.place(&tile{Paths: p.paths}, p.row, p.col); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := b.moveGems(p.row, p.col, result); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for idx, expected := range test.expected {
+				if row, col, edge := b.gems[idx].location(); row != expected.row || col != expected.col || edge != expected.edge {
+					t.Errorf("gem %d at (%d, %d) %s but expected (%d, %d) %s", idx, row, col, edge, expected.row, expected.col, expected.edge)
+				}
+			}
+			for idx, gem := range b.gems {
+				if gem.collided != test.collided[idx] {
+					t.Errorf("gem %d collided %t but expected %t", idx, gem.collided, !gem.collided)
+				}
+				if edges, ok := test.scored[idx]; ok != (gem.gateway >= 0) || (ok && b.gateways[gem.gateway].Edges != edges) {
+					t.Errorf("gem %d reached gateway %d but expected %q", idx, gem.gateway, edges)
+				}
+			}
+			if len(result.Collisions) != len(test.collisions) {
+				t.Fatalf("%d collisions but expected %d", len(result.Collisions), len(test.collisions))
+			}
+			for idx, collision := range result.Collisions {
+				if collision.Gems != test.collisions[idx] {
+					t.Errorf("collision between gems %v but expected %v", collision.Gems, test.collisions[idx])
+				}
+			}
+		})
+	}
+}
+
+// chainReaction returns a board and a placement on it that moves several gems across multiple tiles
+func chainReaction(b *testing.B) (*board, *tile, int, int) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 3},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for len(game.state.winners) == 0 {
+		for _, move := range game.state.legalMoves() {
+			details := move.MoreDetails.(PlaceTileActionDetails)
+			result, _ := game.Preview(move.Team, details.Tile, details.Row, details.Column)
+			steps := 0
+			for _, path := range result.Paths {
+				steps += len(path.Steps)
+			}
+			if steps >= 6 {
+				return game.state.board, &tile{Paths: details.Tile}, details.Row, details.Column
+			}
+		}
+		if err := game.Do(game.state.legalMoves()[0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.Fatal("no chain reaction found")
+	return nil, nil, 0, 0
+}
+
+func BenchmarkMoveGems(b *testing.B) {
+	start, t, row, col := chainReaction(b)
+	// moving gems changes the board so a small ring of copies is refilled outside the timer once used up
+	boards := make([]*board, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%len(boards) == 0 {
+			b.StopTimer()
+			for j := range boards {
+				boards[j] = start.clone()
+				boards[j].setTile(cellIndex(row, col), t)
+			}
+			b.StartTimer()
+		}
+		if _, err := boards[i%len(boards)].moveGems(row, col, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetAdjacent(b *testing.B) {
+	board := newBoard([]string{"red", "blue"})
+	for i := 0; i < b.N; i++ {
+		for _, edge := range []string{A, B, C, D, E, F} {
+			board.getAdjacent(i%rows, 2, edge)
+		}
+	}
+}
+
+func BenchmarkRotateClockwise(b *testing.B) {
+	t := &tile{Paths: uniquePaths[2]}
+	for i := 0; i < b.N; i++ {
+		t.RotateClockwise()
+	}
+}
+
+func BenchmarkTileEquals(b *testing.B) {
+	t1, t2 := &tile{Paths: uniquePaths[3]}, &tile{Paths: uniquePaths[3]}
+	t2.RotateClockwise()
+	for i := 0; i < b.N; i++ {
+		t1.equals(t2)
+	}
+}
+
+func BenchmarkLegalMoves(b *testing.B) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 3, Variant: VariantLargeHands},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		_ = game.Do(game.state.legalMoves()[0])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.state.legalMoves()
+	}
+}
+
+// clonedBoard keeps the result of BenchmarkBoardClone so the copy is not optimized away
+var clonedBoard *board
+
+func BenchmarkBoardClone(b *testing.B) {
+	start, _, _, _ := chainReaction(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clonedBoard = start.clone()
+	}
+}
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"fmt"
+	"maps"
+
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+)
+
+// Player chooses the next action for a team in a game of Indigo
+type Player interface {
+	// Action returns a legal place tile action for team without changing game
+	Action(game *indigo.Indigo, team string) (*bg.BoardGameAction, error)
+}
+
+// moves returns every legal place tile action for team
+func moves(game *indigo.Indigo, team string) ([]*bg.BoardGameAction, error) {
+	snapshot, err := game.GetSnapshot(team)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot.Winners) > 0 {
+		return nil, fmt.Errorf("game already over")
+	}
+	if snapshot.Turn != team {
+		return nil, fmt.Errorf("%s cannot play on %s turn", team, snapshot.Turn)
+	}
+	moves := make([]*bg.BoardGameAction, 0)
+	targets, _ := snapshot.Targets.([]*bg.BoardGameAction)
+	for _, target := range targets {
+		if target.ActionType == indigo.ActionPlaceTile && target.Team == team {
+			moves = append(moves, target)
+		}
+	}
+	if len(moves) == 0 {
+		return nil, errNoLegalMove(team)
+	}
+	return moves, nil
+}
+
+// errNoLegalMove is returned when the board rejects every tile team could place
+func errNoLegalMove(team string) error {
+	return fmt.Errorf("%s has no tile that can be placed", team)
+}
+
+// points returns a copy of the current points of every team as the snapshot shares the game's map which later moves change
+func points(game *indigo.Indigo) map[string]int {
+	snapshot, _ := game.GetSnapshot()
+	return maps.Clone(snapshot.MoreData.(indigo.IndigoSnapshotData).Points)
+}
+
+// advantage is how far ahead team is of its best opponent
+func advantage(points map[string]int, team string) int {
+	best := 0
+	found := false
+	for t, p := range points {
+		if t != team && (!found || p > best) {
+			best = p
+			found = true
+		}
+	}
+	return points[team] - best
+}
+
+// Names are the names of the players New can create
+var Names = []string{"random", "greedy", "search", "mcts"}
+
+// New creates the player with the given name using default settings
+func New(name string, seed int64) (Player, error) {
+	switch name {
+	case "random":
+		return NewRandom(seed), nil
+	case "greedy":
+		return NewGreedy(seed), nil
+	case "search":
+		return NewSearch(seed, 1)
+	case "mcts":
+		return NewMCTS(seed, 200, 0)
+	}
+	return nil, fmt.Errorf("unknown player %s", name)
+}
@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+)
+
+// play runs a full game between players returning its final snapshot
+func play(t *testing.T, seed int64, players map[string]Player) string {
+	teams := []string{"red", "blue"}
+	game, err := indigo.NewIndigo(&bg.BoardGameOptions{
+		Teams:       teams,
+		MoreOptions: indigo.IndigoMoreOptions{Seed: seed, RoundsUntilEnd: 6},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		snapshot, _ := game.GetSnapshot()
+		if len(snapshot.Winners) > 0 {
+			raw, err := json.Marshal(snapshot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return string(raw)
+		}
+		action, err := players[snapshot.Turn].Action(game, snapshot.Turn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := game.Do(action); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_PlayersAreDeterministic(t *testing.T) {
+	search, _ := NewSearch(3, 1)
+	first := play(t, 42, map[string]Player{"red": NewRandom(1), "blue": NewGreedy(2)})
+	second := play(t, 42, map[string]Player{"red": NewRandom(1), "blue": NewGreedy(2)})
+	if first != second {
+		t.Fatal("same seeds produced different games")
+	}
+	play(t, 42, map[string]Player{"red": search, "blue": NewGreedy(2)})
+}
+
+func Test_MCTS(t *testing.T) {
+	mcts, err := NewMCTS(5, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	play(t, 42, map[string]Player{"red": mcts, "blue": NewRandom(2)})
+}
+
+func Test_PointsAreCopied(t *testing.T) {
+	game, err := indigo.NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: indigo.IndigoMoreOptions{Seed: 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := points(game)
+	player := NewRandom(4)
+	for total := 0; total == 0; {
+		snapshot, _ := game.GetSnapshot()
+		if len(snapshot.Winners) > 0 {
+			t.Fatal("game ended before any points were scored")
+		}
+		action, err := player.Action(game, snapshot.Turn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := game.Do(action); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range points(game) {
+			total += p
+		}
+	}
+	for team, p := range before {
+		if p != 0 {
+			t.Fatalf("points of %s taken before any move changed to %d", team, p)
+		}
+	}
+}
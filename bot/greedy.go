@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"math/rand"
+
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+)
+
+// Greedy places the tile that gains the most points for its own gateways while giving away the fewest to opponents
+// Ties are broken randomly
+type Greedy struct {
+	r *rand.Rand
+}
+
+func NewGreedy(seed int64) *Greedy {
+	return &Greedy{
+		r: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (p *Greedy) Action(game *indigo.Indigo, team string) (*bg.BoardGameAction, error) {
+	game = game.Clone()
+	moves, err := moves(game, team)
+	if err != nil {
+		return nil, err
+	}
+	before := points(game)
+	best := make([]*bg.BoardGameAction, 0)
+	bestScore := 0
+	for _, move := range moves {
+		if err := game.Do(move); err != nil {
+			continue
+		}
+		after := points(game)
+		_ = game.Undo(1)
+		score := 0
+		for t := range after {
+			if t == team {
+				score += after[t] - before[t]
+			} else {
+				score -= after[t] - before[t]
+			}
+		}
+		if len(best) == 0 || score > bestScore {
+			best = []*bg.BoardGameAction{move}
+			bestScore = score
+		} else if score == bestScore {
+			best = append(best, move)
+		}
+	}
+	if len(best) == 0 {
+		return nil, errNoLegalMove(team)
+	}
+	return best[p.r.Intn(len(best))], nil
+}
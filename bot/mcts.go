@@ -0,0 +1,175 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+)
+
+// exploration is the UCB constant balancing trying new tiles against replaying good ones
+const exploration = 0.7
+
+// MCTS runs an information set Monte Carlo tree search from team's view of the game
+// Each iteration deals the hidden hands and deck at random from the tiles team has not seen
+// so the search never uses information team could not know
+type MCTS struct {
+	iterations int
+	duration   time.Duration
+	r          *rand.Rand
+}
+
+// NewMCTS creates a search that stops after the given number of iterations or duration whichever comes first
+// A zero iterations or duration means no limit of that kind but at least one must be set
+func NewMCTS(seed int64, iterations int, duration time.Duration) (*MCTS, error) {
+	if iterations <= 0 && duration <= 0 {
+		return nil, fmt.Errorf("mcts requires an iteration or duration budget")
+	}
+	return &MCTS{
+		iterations: iterations,
+		duration:   duration,
+		r:          rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// node is a tile placement in the search tree shared across every dealing of hidden tiles
+type node struct {
+	parent   *node
+	children []*node
+	move     *bg.BoardGameAction
+	key      string
+	visits   int
+	avails   int     // times this move could have been chosen given the dealt tiles
+	reward   float64 // total reward for the team that placed the tile
+}
+
+func (p *MCTS) Action(game *indigo.Indigo, team string) (*bg.BoardGameAction, error) {
+	if _, err := moves(game, team); err != nil {
+		return nil, err
+	}
+	root := &node{}
+	start := time.Now()
+	for i := 0; p.iterations <= 0 || i < p.iterations; i++ {
+		if p.duration > 0 && time.Since(start) >= p.duration {
+			break
+		}
+		determinized, err := game.Determinize(team, p.r.Int63())
+		if err != nil {
+			return nil, err
+		}
+		p.iterate(root, determinized)
+	}
+	var best *node
+	for _, child := range root.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+	if best == nil {
+		return nil, errNoLegalMove(team)
+	}
+	return best.move, nil
+}
+
+// iterate selects and expands a path through the tree, plays the rest of the game at random and records the result
+func (p *MCTS) iterate(root *node, game *indigo.Indigo) {
+	n := root
+	for {
+		snapshot, _ := game.GetSnapshot()
+		if len(snapshot.Winners) > 0 {
+			break
+		}
+		candidates, err := moves(game, snapshot.Turn)
+		if err != nil {
+			break
+		}
+
+		// mark which children the dealt tiles allow and collect the untried moves
+		available := make([]*node, 0)
+		untried := make([]*bg.BoardGameAction, 0)
+		for _, move := range candidates {
+			if child := n.child(key(move)); child != nil {
+				child.avails++
+				available = append(available, child)
+			} else {
+				untried = append(untried, move)
+			}
+		}
+
+		// expand a random untried move
+		if len(untried) > 0 {
+			move := untried[p.r.Intn(len(untried))]
+			if err := game.Do(move); err != nil {
+				break
+			}
+			child := &node{parent: n, move: move, key: key(move), avails: 1}
+			n.children = append(n.children, child)
+			n = child
+			break
+		}
+
+		// otherwise follow the available child with the highest upper confidence bound
+		var best *node
+		bestUCB := math.Inf(-1)
+		for _, child := range available {
+			ucb := child.reward/float64(child.visits) + exploration*math.Sqrt(math.Log(float64(child.avails))/float64(child.visits))
+			if ucb > bestUCB {
+				best = child
+				bestUCB = ucb
+			}
+		}
+		if best == nil || game.Do(best.move) != nil {
+			break
+		}
+		n = best
+	}
+
+	rewards := p.rollout(game)
+	for ; n != nil; n = n.parent {
+		n.visits++
+		if n.move != nil {
+			n.reward += rewards[n.move.Team]
+		}
+	}
+}
+
+// rollout plays random tiles until the game ends returning a share of one for each winner
+func (p *MCTS) rollout(game *indigo.Indigo) map[string]float64 {
+	for {
+		snapshot, _ := game.GetSnapshot()
+		if len(snapshot.Winners) > 0 {
+			rewards := make(map[string]float64)
+			for _, winner := range snapshot.Winners {
+				rewards[winner] = 1 / float64(len(snapshot.Winners))
+			}
+			return rewards
+		}
+		candidates, err := moves(game, snapshot.Turn)
+		if err != nil {
+			return map[string]float64{}
+		}
+		if err := game.Do(candidates[p.r.Intn(len(candidates))]); err != nil {
+			return map[string]float64{}
+		}
+	}
+}
+
+func (n *node) child(key string) *node {
+	for _, child := range n.children {
+		if child.key == key {
+			return child
+		}
+	}
+	return nil
+}
+
+// key uniquely identifies a place tile action
+func key(action *bg.BoardGameAction) string {
+	var details indigo.PlaceTileActionDetails
+	_ = mapstructure.Decode(action.MoreDetails, &details)
+	return fmt.Sprintf("%s.%d.%d", details.Tile, details.Row, details.Column)
+}
@@ -0,0 +1,27 @@
+package bot
+
+import (
+	"math/rand"
+
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+)
+
+// Random places a random legal tile
+type Random struct {
+	r *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{
+		r: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (p *Random) Action(game *indigo.Indigo, team string) (*bg.BoardGameAction, error) {
+	moves, err := moves(game, team)
+	if err != nil {
+		return nil, err
+	}
+	return moves[p.r.Intn(len(moves))], nil
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"fmt"
+	"math/rand"
+
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+)
+
+// Search looks depth tiles ahead assuming every team places the tile that puts it furthest ahead of its best opponent
+// NOTE - search plays with perfect information as it sees every hand and the order of the deck
+type Search struct {
+	depth int
+	r     *rand.Rand
+}
+
+func NewSearch(seed int64, depth int) (*Search, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("search depth must be at least 1")
+	}
+	return &Search{
+		depth: depth,
+		r:     rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+func (p *Search) Action(game *indigo.Indigo, team string) (*bg.BoardGameAction, error) {
+	game = game.Clone()
+	moves, err := moves(game, team)
+	if err != nil {
+		return nil, err
+	}
+	best := make([]*bg.BoardGameAction, 0)
+	bestScore := 0
+	for _, move := range moves {
+		if err := game.Do(move); err != nil {
+			continue
+		}
+		score := advantage(p.search(game, p.depth-1), team)
+		_ = game.Undo(1)
+		if len(best) == 0 || score > bestScore {
+			best = []*bg.BoardGameAction{move}
+			bestScore = score
+		} else if score == bestScore {
+			best = append(best, move)
+		}
+	}
+	if len(best) == 0 {
+		return nil, errNoLegalMove(team)
+	}
+	return best[p.r.Intn(len(best))], nil
+}
+
+// search returns the points every team ends with after depth more tiles are placed
+func (p *Search) search(game *indigo.Indigo, depth int) map[string]int {
+	snapshot, _ := game.GetSnapshot()
+	if depth <= 0 || len(snapshot.Winners) > 0 {
+		return snapshot.MoreData.(indigo.IndigoSnapshotData).Points
+	}
+	team := snapshot.Turn
+	best := snapshot.MoreData.(indigo.IndigoSnapshotData).Points
+	moves, err := moves(game, team)
+	if err != nil {
+		return best
+	}
+	found := false
+	for _, move := range moves {
+		if err := game.Do(move); err != nil {
+			continue
+		}
+		result := p.search(game, depth-1)
+		_ = game.Undo(1)
+		if !found || advantage(result, team) > advantage(best, team) {
+			best = result
+			found = true
+		}
+	}
+	return best
+}
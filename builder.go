@@ -34,14 +34,21 @@ func (b *Builder) Load(game *bgn.Game) (bg.BoardGameWithBGN, error) {
 	if !(variantStr == "" || contains(variants, variantStr)) {
 		return nil, errDecoding(fmt.Errorf("invalid variant value"))
 	}
+	commitment, committed := game.Tags["SeedCommitment"]
 	seedStr, ok := game.Tags["Seed"]
-	if !ok {
+	if !ok && committed {
+		return nil, errDecoding(fmt.Errorf("seed is not revealed until the game is over"))
+	} else if !ok {
 		return nil, errDecoding(fmt.Errorf("missing seed tag"))
 	}
 	seed, err := strconv.Atoi(seedStr)
 	if err != nil {
 		return nil, errDecoding(err)
 	}
+	salt := game.Tags["SeedSalt"]
+	if committed && seedCommitment(int64(seed), salt) != commitment {
+		return nil, errDecoding(fmt.Errorf("seed does not match the seed commitment"))
+	}
 	roundsUntilEndStr := game.Tags["RoundsUntilEnd"]
 	var roundsUntilEnd int
 	if roundsUntilEndStr != "" {
@@ -57,13 +64,14 @@ func (b *Builder) Load(game *bgn.Game) (bg.BoardGameWithBGN, error) {
 			Seed:           int64(seed),
 			Variant:        variantStr,
 			RoundsUntilEnd: roundsUntilEnd,
+			Salt:           salt,
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
 	for _, action := range game.Actions {
-		if action.TeamIndex >= len(teams) {
+		if action.TeamIndex < 0 || action.TeamIndex >= len(teams) {
 			return nil, errDecoding(fmt.Errorf("team index %d out of range", action.TeamIndex))
 		}
 		team := teams[action.TeamIndex]
@@ -73,6 +81,12 @@ func (b *Builder) Load(game *bgn.Game) (bg.BoardGameWithBGN, error) {
 		}
 		var details interface{}
 		switch actionType {
+		case ActionRotateTileClockwise:
+			result, err := decodeRotateTileActionDetailsBGN(action.Details)
+			if err != nil {
+				return nil, err
+			}
+			details = result
 		case ActionPlaceTile:
 			result, err := decodePlaceTileActionDetailsBGN(action.Details)
 			if err != nil {
@@ -80,6 +94,12 @@ func (b *Builder) Load(game *bgn.Game) (bg.BoardGameWithBGN, error) {
 			}
 			details = result
 		case bg.ActionSetWinners:
+			// negative indexes are not caught by the decoder and would panic
+			for _, detail := range action.Details {
+				if idx, err := strconv.Atoi(detail); err == nil && idx < 0 {
+					return nil, errDecoding(fmt.Errorf("winner index %d out of range", idx))
+				}
+			}
 			result, err := bg.DecodeSetWinnersActionDetailsBGN(action.Details, teams)
 			if err != nil {
 				return nil, err
@@ -0,0 +1,42 @@
+package go_indigo
+
+import (
+	"testing"
+
+	"github.com/quibbble/go-boardgame/pkg/bgn"
+)
+
+func FuzzLoad(f *testing.F) {
+	for _, seed := range []string{
+		"[Game \"Indigo\"][Teams \"red, blue\"][Seed \"1696338136223\"]\n\n0p&8.3.ADBFCE 1p&8.1.FEABCD 0p&8.2.BACDEF",
+		"[Game \"Indigo\"][Teams \"red, blue, green\"][Seed \"5\"][Variant \"LargeHands\"][RoundsUntilEnd \"3\"]\n\n0r&ADBFCE 0p&1.1.ADBFCE",
+		"[Game \"Indigo\"][Teams \"red, blue\"][Seed \"2\"]\n\n-1p&8.3.ADBFCE",
+		"[Game \"Indigo\"][Teams \"red, blue\"][Seed \"2\"]\n\n0p&-8.3.ADBFCE 1p&4.99.ABC",
+		"[Game \"Indigo\"][Teams \"red, blue\"][Seed \"2\"]\n\n0p&4.4.S 0r&ABCDE 0w&1",
+		"[Game \"Indigo\"][Teams \"red\"][Seed \"x\"]",
+	} {
+		f.Add(seed)
+	}
+	builder := Builder{}
+	f.Fuzz(func(t *testing.T, raw string) {
+		game, err := bgn.Parse(raw)
+		if err != nil {
+			return
+		}
+		_, _ = builder.Load(game)
+	})
+}
+
+func BenchmarkLoad(b *testing.B) {
+	game, err := bgn.Parse(fullGame(b).GetBGN().String())
+	if err != nil {
+		b.Fatal(err)
+	}
+	builder := Builder{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := builder.Load(game); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
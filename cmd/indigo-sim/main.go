@@ -0,0 +1,43 @@
+// Command indigo-sim plays many games of Indigo between bots and prints statistics about them
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	indigo "github.com/quibbble/go-indigo"
+	"github.com/quibbble/go-indigo/bot"
+	"github.com/quibbble/go-indigo/sim"
+)
+
+func main() {
+	games := flag.Int("games", 100, "number of games to play")
+	seed := flag.Int64("seed", 0, "seed of the first game with each following game using the next seed")
+	teams := flag.Int("teams", 2, "number of teams in each game")
+	variant := flag.String("variant", indigo.VariantClassic, "game variant i.e. Classic or LargeHands")
+	rounds := flag.Int("rounds", 0, "number of rounds until each game ends where 0 plays until every gem is gone")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of games played at once")
+	players := flag.String("players", "greedy", "comma separated list of players by seat, repeated if shorter than teams, from "+strings.Join(bot.Names, ", "))
+	flag.Parse()
+
+	names := strings.Split(*players, ",")
+	report, err := sim.Run(sim.Config{
+		Games:          *games,
+		Seed:           *seed,
+		Teams:          *teams,
+		Variant:        *variant,
+		RoundsUntilEnd: *rounds,
+		Workers:        *workers,
+		Players: func(seat int, seed int64) (bot.Player, error) {
+			return bot.New(names[seat%len(names)], seed)
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(report)
+}
@@ -0,0 +1,295 @@
+// Command indigo plays a hot seat game of Indigo in the terminal between humans and bots
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
+	indigo "github.com/quibbble/go-indigo"
+	"github.com/quibbble/go-indigo/bot"
+)
+
+const help = `commands:
+  board                     show the board, points and the current team's hand
+  scores                    show the points of every team
+  moves                     list every legal place for the current team
+  rotate <tile|n>           rotate a tile in the current team's hand clockwise
+  place <tile|n> <row> <col> place a tile from the current team's hand
+  undo                      take back the last placed tile
+  save <file>               save the game as BGN
+  load <file>               load a game saved as BGN
+  help                      show this message
+  quit                      exit the game
+tiles may be given by their paths i.e. ABCDEF or by their position n in hand starting at 1`
+
+func main() {
+	teams := flag.String("teams", "red,blue", "comma separated list of 2 to 4 teams")
+	bots := flag.String("bots", "", "comma separated list of team=bot where bot is "+strings.Join(bot.Names, ", "))
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed used to shuffle the deck and drive the bots")
+	variant := flag.String("variant", indigo.VariantClassic, "game variant i.e. Classic or LargeHands")
+	rounds := flag.Int("rounds", 0, "number of rounds until the game ends where 0 plays until every gem is gone")
+	load := flag.String("load", "", "BGN file to load instead of creating a new game")
+	flag.Parse()
+
+	s := &session{
+		builder: &indigo.Builder{},
+		bots:    make(map[string]bot.Player),
+		out:     os.Stdout,
+	}
+	if *load != "" {
+		if err := s.load(*load); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		game, err := s.builder.Create(&bg.BoardGameOptions{
+			Teams: strings.Split(*teams, ","),
+			MoreOptions: indigo.IndigoMoreOptions{
+				Seed:           *seed,
+				Variant:        *variant,
+				RoundsUntilEnd: *rounds,
+			},
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		s.game = game.(*indigo.Indigo)
+	}
+	if *bots != "" {
+		if err := s.assignBots(strings.Split(*bots, ","), *seed); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	s.run(os.Stdin)
+}
+
+type session struct {
+	builder *indigo.Builder
+	game    *indigo.Indigo
+	bots    map[string]bot.Player
+	out     io.Writer
+}
+
+// assignBots gives each team in assignments of the form team=bot to a bot so a typo cannot leave a seat to a human
+func (s *session) assignBots(assignments []string, seed int64) error {
+	snapshot, _ := s.game.GetSnapshot()
+	for idx, assignment := range assignments {
+		team, name, _ := strings.Cut(assignment, "=")
+		if !contains(snapshot.Teams, team) {
+			return fmt.Errorf("cannot assign a bot to unknown team %s", team)
+		}
+		if _, ok := s.bots[team]; ok {
+			return fmt.Errorf("team %s assigned more than one bot", team)
+		}
+		player, err := bot.New(name, seed+int64(idx))
+		if err != nil {
+			return err
+		}
+		s.bots[team] = player
+	}
+	return nil
+}
+
+func (s *session) run(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	s.board()
+	for {
+		snapshot, _ := s.game.GetSnapshot()
+		if len(snapshot.Winners) == 0 {
+			if player, ok := s.bots[snapshot.Turn]; ok {
+				if err := s.playBot(player, snapshot.Turn); err != nil {
+					fmt.Fprintln(s.out, err)
+					return
+				}
+				continue
+			}
+		}
+		fmt.Fprintf(s.out, "%s> ", snapshot.Message)
+		if !scanner.Scan() {
+			fmt.Fprintln(s.out)
+			return
+		}
+		args := strings.Fields(scanner.Text())
+		if len(args) == 0 {
+			continue
+		}
+		if args[0] == "quit" || args[0] == "exit" {
+			return
+		}
+		if err := s.command(args[0], args[1:]); err != nil {
+			fmt.Fprintln(s.out, err)
+		}
+	}
+}
+
+func (s *session) command(name string, args []string) error {
+	snapshot, _ := s.game.GetSnapshot()
+	switch name {
+	case "board":
+		s.board()
+	case "scores":
+		data := snapshot.MoreData.(indigo.IndigoSnapshotData)
+		for _, team := range snapshot.Teams {
+			fmt.Fprintf(s.out, "%s: %d\n", team, data.Points[team])
+		}
+	case "moves":
+		turn, _ := s.game.GetSnapshot(snapshot.Turn)
+		for _, target := range turn.Targets.([]*bg.BoardGameAction) {
+			if target.ActionType == indigo.ActionPlaceTile {
+				details := target.MoreDetails.(indigo.PlaceTileActionDetails)
+				fmt.Fprintf(s.out, "place %s %d %d\n", details.Tile, details.Row, details.Column)
+			}
+		}
+	case "rotate":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: rotate <tile|n>")
+		}
+		tile, err := s.tile(snapshot.Turn, args[0])
+		if err != nil {
+			return err
+		}
+		if err := s.game.Do(&bg.BoardGameAction{
+			Team:        snapshot.Turn,
+			ActionType:  indigo.ActionRotateTileClockwise,
+			MoreDetails: indigo.RotateTileActionDetails{Tile: tile},
+		}); err != nil {
+			return err
+		}
+		s.hand(snapshot.Turn)
+	case "place":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: place <tile|n> <row> <col>")
+		}
+		tile, err := s.tile(snapshot.Turn, args[0])
+		if err != nil {
+			return err
+		}
+		row, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid row %s", args[1])
+		}
+		col, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid column %s", args[2])
+		}
+		if err := s.game.Do(&bg.BoardGameAction{
+			Team:        snapshot.Turn,
+			ActionType:  indigo.ActionPlaceTile,
+			MoreDetails: indigo.PlaceTileActionDetails{Tile: tile, Row: row, Column: col},
+		}); err != nil {
+			return err
+		}
+		s.board()
+	case "undo":
+		// take back bot moves along with the last human move so it is a human's turn again
+		if err := s.game.Undo(1); err != nil {
+			return err
+		}
+		for {
+			snapshot, _ := s.game.GetSnapshot()
+			if _, ok := s.bots[snapshot.Turn]; !ok || s.game.Undo(1) != nil {
+				break
+			}
+		}
+		s.board()
+	case "save":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: save <file>")
+		}
+		return os.WriteFile(args[0], []byte(s.game.GetBGN().String()+"\n"), 0644)
+	case "load":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: load <file>")
+		}
+		if err := s.load(args[0]); err != nil {
+			return err
+		}
+		s.board()
+	case "help":
+		fmt.Fprintln(s.out, help)
+	default:
+		return fmt.Errorf("unknown command %s, type help for a list of commands", name)
+	}
+	return nil
+}
+
+func (s *session) playBot(player bot.Player, team string) error {
+	action, err := player.Action(s.game, team)
+	if err != nil {
+		return err
+	}
+	if err := s.game.Do(action); err != nil {
+		return err
+	}
+	details := action.MoreDetails.(indigo.PlaceTileActionDetails)
+	fmt.Fprintf(s.out, "%s placed %s at (%d, %d)\n", team, details.Tile, details.Row, details.Column)
+	s.board()
+	return nil
+}
+
+// board shows the board with only the current team's hand as other players may be watching
+func (s *session) board() {
+	full, _ := s.game.GetSnapshot()
+	snapshot, _ := s.game.GetSnapshot(full.Turn)
+	data := snapshot.MoreData.(indigo.IndigoSnapshotData)
+	fmt.Fprint(s.out, indigo.RenderText(&data))
+	fmt.Fprintln(s.out, snapshot.Message)
+}
+
+func (s *session) hand(team string) {
+	snapshot, _ := s.game.GetSnapshot(team)
+	data := snapshot.MoreData.(indigo.IndigoSnapshotData)
+	for idx, tile := range data.Hands[team] {
+		fmt.Fprintf(s.out, "%d: %s\n", idx+1, tile.Paths)
+	}
+}
+
+// tile returns the paths of the tile in team's hand given either its paths or its position in hand starting at 1
+func (s *session) tile(team, arg string) (string, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return strings.ToUpper(arg), nil
+	}
+	snapshot, _ := s.game.GetSnapshot(team)
+	hand := snapshot.MoreData.(indigo.IndigoSnapshotData).Hands[team]
+	if n < 1 || n > len(hand) {
+		return "", fmt.Errorf("hand only has %d tiles", len(hand))
+	}
+	return hand[n-1].Paths, nil
+}
+
+func (s *session) load(file string) error {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	game, err := bgn.Parse(string(raw))
+	if err != nil {
+		return err
+	}
+	loaded, err := s.builder.Load(game)
+	if err != nil {
+		return err
+	}
+	s.game = loaded.(*indigo.Indigo)
+	return nil
+}
+
+func contains(teams []string, team string) bool {
+	for _, t := range teams {
+		if t == team {
+			return true
+		}
+	}
+	return false
+}
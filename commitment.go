@@ -0,0 +1,78 @@
+package go_indigo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
+)
+
+// newSalt returns a random secret to mix with the seed when committing to it
+func newSalt() (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// seedCommitment returns the hash that commits to the seed without revealing it
+func seedCommitment(seed int64, salt string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", salt, seed)))
+	return hex.EncodeToString(sum[:])
+}
+
+// CommitSeed creates the random salt the seed commitment in GetPlayerBGN is made with unless the game already has one
+// The salt is kept from then on, in GetBGN too, so the commitment never changes and loading the game keeps it
+// NOTE - like Do this changes the game so must not be called at the same time as anything else on the game
+func (i *Indigo) CommitSeed() error {
+	if i.options.Salt != "" {
+		return nil
+	}
+	salt, err := newSalt()
+	if err != nil {
+		return &bgerr.Error{
+			Err:    err,
+			Status: bgerr.StatusBGNEncodingFailure,
+		}
+	}
+	i.options.Salt = salt
+	return nil
+}
+
+// GetPlayerBGN returns BGN that is safe to give to players while the game is running
+// The seed, from which the deck and every hand can be rebuilt, is replaced by a commitment to it and
+// tile rotations, which reveal the tiles in hand, are left out until the game is over at which point the
+// seed, the salt needed to verify the commitment and every action are included so the game can be loaded
+// NOTE - the game must have a salt from CommitSeed or the options and this never changes the game
+func (i *Indigo) GetPlayerBGN() (*bgn.Game, error) {
+	if i.options.Salt == "" {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("seed not committed to so call CommitSeed or give a Salt option first"),
+			Status: bgerr.StatusBGNEncodingFailure,
+		}
+	}
+	tags := map[string]string{
+		"Game":           key,
+		"Teams":          strings.Join(i.state.teams, ", "),
+		"Variant":        i.options.Variant,
+		"RoundsUntilEnd": fmt.Sprintf("%d", i.options.RoundsUntilEnd),
+		"SeedCommitment": seedCommitment(i.options.Seed, i.options.Salt),
+	}
+	if len(i.state.winners) == 0 {
+		return &bgn.Game{
+			Tags:    tags,
+			Actions: i.encodeActions(i.visibleActions("")),
+		}, nil
+	}
+	tags["Seed"] = fmt.Sprintf("%d", i.options.Seed)
+	tags["SeedSalt"] = i.options.Salt
+	return &bgn.Game{
+		Tags:    tags,
+		Actions: i.encodeActions(i.actions),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package go_indigo
+
+import (
+	"fmt"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+	cl "github.com/quibbble/go-boardgame/pkg/collection"
+)
+
+// Determinize returns a copy of the game as team could believe it to be
+// Everything team cannot see, the other teams' hands and the order of the deck, is dealt at random
+// from the tiles team has not seen so the copy reveals nothing team does not already know
+func (i *Indigo) Determinize(team string, seed int64) (*Indigo, error) {
+	if !contains(i.state.teams, team) {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("%s not a valid team", team),
+			Status: bgerr.StatusUnknownTeam,
+		}
+	}
+	state := i.state.clone()
+
+	// count the copies of each tile team has not seen on the board or in its hand
+	unseen := append(make([]int, 0), numCopiesByUniquePathsIndex...)
+	for cell := range state.board.tiles {
+		if t := &state.board.tiles[cell]; t.Paths != "" && !t.Treasure {
+			if idx := t.uniqueIndex(); idx >= 0 {
+				unseen[idx]--
+			}
+		}
+	}
+	for _, t := range state.hands[team].GetItems() {
+		if idx := t.uniqueIndex(); idx >= 0 {
+			unseen[idx]--
+		}
+	}
+
+	deck := cl.NewCollection[tile](seed)
+	for idx, numCopies := range unseen {
+		for j := 0; j < numCopies; j++ {
+			deck.Add(tile{Paths: uniquePaths[idx]})
+		}
+	}
+	deck.Shuffle()
+
+	for _, t := range state.teams {
+		if t == team {
+			continue
+		}
+		hand := cl.NewCollection[tile](0)
+		for j := 0; j < state.hands[t].GetSize(); j++ {
+			drawn, err := deck.Draw()
+			if err != nil {
+				return nil, err
+			}
+			hand.Add(*drawn)
+		}
+		state.hands[t] = hand
+	}
+	for deck.GetSize() > state.deck.GetSize() {
+		_ = deck.Remove(deck.GetSize() - 1)
+	}
+	state.deck = deck
+
+	options := *i.options
+	return &Indigo{
+		state:   state,
+		actions: append(make([]*bg.BoardGameAction, 0), i.actions...),
+		options: &options,
+		undos:   make([]*checkpoint, 0),
+		redos:   make([]*checkpoint, 0),
+	}, nil
+}
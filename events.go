@@ -0,0 +1,125 @@
+package go_indigo
+
+// Event types
+const (
+	EventTilePlaced    = "TilePlaced"
+	EventTileRotated   = "TileRotated"
+	EventGemMoved      = "GemMoved"
+	EventGemsCollided  = "GemsCollided"
+	EventGemScored     = "GemScored"
+	EventTileDrawn     = "TileDrawn"
+	EventTurnChanged   = "TurnChanged"
+	EventTurnSkipped   = "TurnSkipped"
+	EventRoundAdvanced = "RoundAdvanced"
+	EventGameOver      = "GameOver"
+	EventUndone        = "Undone"
+	EventRedone        = "Redone"
+)
+
+// Event is a change to the game sent to every observer
+type Event interface {
+	EventType() string
+}
+
+// Observer is notified of every event in a game as it happens
+// NOTE - undo and redo restore a saved copy of the game so only send an Undone or Redone event
+type Observer interface {
+	Notify(event Event)
+}
+
+// ObserverFunc allows a function to be used as an Observer
+type ObserverFunc func(event Event)
+
+func (f ObserverFunc) Notify(event Event) {
+	f(event)
+}
+
+type TilePlacedEvent struct {
+	Team        string
+	Tile        string
+	Row, Column int
+}
+
+// TileRotatedEvent is sent when a team rotates a tile in its hand
+// NOTE - the tile is hidden information so is not sent to every observer
+type TileRotatedEvent struct {
+	Team string
+}
+
+type GemMovedEvent struct {
+	GemPath
+}
+
+type GemsCollidedEvent struct {
+	GemCollision
+}
+
+type GemScoredEvent struct {
+	GemScore
+}
+
+// TileDrawnEvent is sent when a team draws a tile
+// NOTE - the tile is hidden information so is not sent to every observer
+type TileDrawnEvent struct {
+	Team string
+}
+
+type TurnChangedEvent struct {
+	Turn string
+}
+
+// TurnSkippedEvent is sent when a team has no tile it can place so its turn is passed to the next team
+type TurnSkippedEvent struct {
+	Team string
+}
+
+type RoundAdvancedEvent struct {
+	Round int
+}
+
+type GameOverEvent struct {
+	Winners []string
+	Points  map[string]int
+}
+
+// UndoneEvent is sent when the last Count tracked actions are undone
+type UndoneEvent struct {
+	Count int
+}
+
+// RedoneEvent is sent when the last Count undone actions are redone
+type RedoneEvent struct {
+	Count int
+}
+
+func (e TilePlacedEvent) EventType() string    { return EventTilePlaced }
+func (e TileRotatedEvent) EventType() string   { return EventTileRotated }
+func (e GemMovedEvent) EventType() string      { return EventGemMoved }
+func (e GemsCollidedEvent) EventType() string  { return EventGemsCollided }
+func (e GemScoredEvent) EventType() string     { return EventGemScored }
+func (e TileDrawnEvent) EventType() string     { return EventTileDrawn }
+func (e TurnChangedEvent) EventType() string   { return EventTurnChanged }
+func (e TurnSkippedEvent) EventType() string   { return EventTurnSkipped }
+func (e RoundAdvancedEvent) EventType() string { return EventRoundAdvanced }
+func (e GameOverEvent) EventType() string      { return EventGameOver }
+func (e UndoneEvent) EventType() string        { return EventUndone }
+func (e RedoneEvent) EventType() string        { return EventRedone }
+
+// Observe registers observer to be notified of every future event
+func (i *Indigo) Observe(observer Observer) {
+	i.observers = append(i.observers, observer)
+	i.state.notify = i.notify
+}
+
+func (i *Indigo) notify(event Event) {
+	for _, observer := range i.observers {
+		observer.Notify(event)
+	}
+}
+
+// emit sends event to the game's observers if there are any
+func (s *state) emit(event Event) {
+	if s.notify != nil {
+		s.notify(event)
+	}
+}
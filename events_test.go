@@ -0,0 +1,59 @@
+package go_indigo
+
+import (
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+func Test_Events(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	game.Observe(ObserverFunc(func(event Event) {
+		counts[event.EventType()]++
+	}))
+
+	hand := game.state.hands["blue"].GetItems()[0]
+	if err := game.Do(&bg.BoardGameAction{
+		Team:        "blue",
+		ActionType:  ActionRotateTileClockwise,
+		MoreDetails: RotateTileActionDetails{Tile: hand.Paths},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	playPlaceTile(t, game)
+	_ = game.Undo(1)
+	_ = game.Redo(1)
+	_ = game.Undo(1)
+	placed := 0
+	for len(game.state.winners) == 0 {
+		playPlaceTile(t, game)
+		placed++
+	}
+
+	if counts[EventTileRotated] != 1 {
+		t.Fatalf("expected 1 %s event but got %d", EventTileRotated, counts[EventTileRotated])
+	}
+	if counts[EventTilePlaced] != placed+1 || counts[EventTurnChanged] != placed+1 {
+		t.Fatalf("expected %d %s events but got %d", placed+1, EventTilePlaced, counts[EventTilePlaced])
+	}
+	if counts[EventUndone] != 2 || counts[EventRedone] != 1 {
+		t.Fatalf("expected 2 %s and 1 %s events but got %d and %d", EventUndone, EventRedone, counts[EventUndone], counts[EventRedone])
+	}
+	if counts[EventGameOver] != 1 {
+		t.Fatalf("expected 1 %s event but got %d", EventGameOver, counts[EventGameOver])
+	}
+	scored := 0
+	for _, result := range game.state.results {
+		scored += len(result.Scores)
+	}
+	if counts[EventGemScored] != scored {
+		t.Fatalf("expected %d %s events but got %d", scored, EventGemScored, counts[EventGemScored])
+	}
+}
@@ -1,13 +1,15 @@
 package go_indigo
 
 type gateway struct {
+	ID        int // stable id of the gateway which is also its index in the board's gateways
 	Locations [3][2]int
 	Edges     string
 	Teams     []string
 }
 
-func newGateway(locations [3][2]int, edges string, teams ...string) *gateway {
+func newGateway(id int, locations [3][2]int, edges string, teams ...string) *gateway {
 	return &gateway{
+		ID:        id,
 		Locations: locations,
 		Edges:     edges,
 		Teams:     teams,
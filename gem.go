@@ -6,6 +6,13 @@ const (
 	Sapphire = "Sapphire"
 )
 
+// Gem statuses
+const (
+	GemInPlay   = "InPlay"   // the gem can still be moved
+	GemCollided = "Collided" // the gem hit another gem and was removed from play
+	GemScored   = "Scored"   // the gem reached a gateway and scored for its owners
+)
+
 var (
 	colorToPoints = map[string]int{
 		Amber:    1,
@@ -15,20 +22,37 @@ var (
 )
 
 type gem struct {
-	Color       string
-	Edge        string
-	Row, Column int
-	collided    bool
-	gateway     *gateway
+	ID       int // stable id of the gem which is also its index in the board's gems
+	Color    string
+	Ply      int // the number of tiles placed when the gem collided or scored or 0 if still in play
+	cell     int // the cell the gem is on
+	edge     int // the index in edgeNames of the edge the gem is on
+	collided bool
+	gateway  int // the index of the gateway the gem reached or -1 if it has not reached one
 }
 
-func newGem(color, edge string, row, column int) *gem {
+func newGem(id int, color, edge string, row, column int) *gem {
 	return &gem{
+		ID:       id,
 		Color:    color,
-		Edge:     edge,
-		Row:      row,
-		Column:   column,
+		cell:     cellIndex(row, column),
+		edge:     edgeIndex[edge[0]],
 		collided: false,
-		gateway:  nil,
+		gateway:  -1,
+	}
+}
+
+// location returns the row, column and edge the gem is on
+func (g *gem) location() (int, int, string) {
+	return cellRows[g.cell], cellColumns[g.cell], edgeNames[g.edge]
+}
+
+// Status returns whether the gem is in play, collided or scored
+func (g *gem) Status() string {
+	if g.gateway >= 0 {
+		return GemScored
+	} else if g.collided {
+		return GemCollided
 	}
+	return GemInPlay
 }
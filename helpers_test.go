@@ -0,0 +1,51 @@
+package go_indigo
+
+import (
+	"encoding/json"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+// playPlaceTile places the first tile in the current team's hand wherever the board accepts it
+func playPlaceTile(t testing.TB, game *Indigo) {
+	for _, target := range game.state.targets() {
+		if target.ActionType != ActionPlaceTile {
+			continue
+		}
+		if err := game.Do(target); err == nil {
+			return
+		}
+	}
+	t.Fatalf("no tile could be placed by %s", game.state.turn)
+}
+
+// dump captures everything needed to compare two games
+func dump(t *testing.T, game *Indigo) string {
+	snapshot, err := game.GetSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(struct {
+		Snapshot *bg.BoardGameSnapshot
+		Deck     []tile
+		Status   [][2]interface{}
+	}{snapshot, game.state.deck.GetItems(), gemStatus(game.state.board)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+// gemStatus returns whether every gem collided and the edges of the gateway it reached if any
+func gemStatus(b *board) [][2]interface{} {
+	status := make([][2]interface{}, 0)
+	for _, gem := range b.gems {
+		edges := ""
+		if gem.gateway >= 0 {
+			edges = b.gateways[gem.gateway].Edges
+		}
+		status = append(status, [2]interface{}{gem.collided, edges})
+	}
+	return status
+}
@@ -19,6 +19,11 @@ type Indigo struct {
 	state   *state
 	actions []*bg.BoardGameAction
 	options *IndigoMoreOptions
+
+	// undos and redos are the saved copies of the game used to undo and redo tracked actions
+	undos, redos []*checkpoint
+
+	observers []Observer
 }
 
 func NewIndigo(options *bg.BoardGameOptions) (*Indigo, error) {
@@ -67,6 +72,8 @@ func NewIndigo(options *bg.BoardGameOptions) (*Indigo, error) {
 		state:   state,
 		actions: make([]*bg.BoardGameAction, 0),
 		options: &details,
+		undos:   make([]*checkpoint, 0),
+		redos:   make([]*checkpoint, 0),
 	}, nil
 }
 
@@ -78,6 +85,48 @@ func (i *Indigo) Do(action *bg.BoardGameAction) error {
 		}
 	}
 	switch action.ActionType {
+	case ActionUndo:
+		if !contains(i.state.teams, action.Team) {
+			return &bgerr.Error{
+				Err:    fmt.Errorf("%s not a valid team", action.Team),
+				Status: bgerr.StatusUnknownTeam,
+			}
+		}
+		var details UndoActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return &bgerr.Error{
+				Err:    err,
+				Status: bgerr.StatusInvalidActionDetails,
+			}
+		}
+		if details.Count == 0 {
+			details.Count = 1
+		}
+		if err := i.undoneBy(action.Team, details.Count); err != nil {
+			return err
+		}
+		return i.Undo(details.Count)
+	case ActionRedo:
+		if !contains(i.state.teams, action.Team) {
+			return &bgerr.Error{
+				Err:    fmt.Errorf("%s not a valid team", action.Team),
+				Status: bgerr.StatusUnknownTeam,
+			}
+		}
+		var details RedoActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return &bgerr.Error{
+				Err:    err,
+				Status: bgerr.StatusInvalidActionDetails,
+			}
+		}
+		if details.Count == 0 {
+			details.Count = 1
+		}
+		if err := i.redoneBy(action.Team, details.Count); err != nil {
+			return err
+		}
+		return i.Redo(details.Count)
 	case ActionRotateTileClockwise:
 		var details RotateTileActionDetails
 		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
@@ -89,6 +138,10 @@ func (i *Indigo) Do(action *bg.BoardGameAction) error {
 		if err := i.state.rotateTileClockwise(action.Team, details.Tile); err != nil {
 			return err
 		}
+		// rotations are not saved for undo as undoing a placed tile also takes back the rotations after it
+		// but a redo would restore a copy without the rotation so nothing can be redone after one
+		i.redos = make([]*checkpoint, 0)
+		i.actions = append(i.actions, action)
 	case ActionPlaceTile:
 		var details PlaceTileActionDetails
 		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
@@ -97,9 +150,11 @@ func (i *Indigo) Do(action *bg.BoardGameAction) error {
 				Status: bgerr.StatusInvalidActionDetails,
 			}
 		}
-		if err := i.state.placeTile(action.Team, details.Tile, details.Row, details.Column); err != nil {
+		before := i.state.clone()
+		if _, err := i.state.placeTile(action.Team, details.Tile, details.Row, details.Column); err != nil {
 			return err
 		}
+		i.save(before, i.actions)
 		i.actions = append(i.actions, action)
 	case bg.ActionSetWinners:
 		var details bg.SetWinnersActionDetails
@@ -109,9 +164,11 @@ func (i *Indigo) Do(action *bg.BoardGameAction) error {
 				Status: bgerr.StatusInvalidActionDetails,
 			}
 		}
+		before := i.state.clone()
 		if err := i.state.setWinners(details.Winners); err != nil {
 			return err
 		}
+		i.save(before, i.actions)
 		i.actions = append(i.actions, action)
 	default:
 		return &bgerr.Error{
@@ -122,6 +179,7 @@ func (i *Indigo) Do(action *bg.BoardGameAction) error {
 	return nil
 }
 
+// GetSnapshot returns the game as seen by team or everything including every hand if no team is given
 func (i *Indigo) GetSnapshot(team ...string) (*bg.BoardGameSnapshot, error) {
 	if len(team) > 1 {
 		return nil, &bgerr.Error{
@@ -136,7 +194,19 @@ func (i *Indigo) GetSnapshot(team ...string) (*bg.BoardGameSnapshot, error) {
 			hands[t] = hand.GetItems()
 		}
 	}
+	actions := i.actions
+	if len(team) == 1 {
+		actions = i.visibleActions(team[0])
+	}
+	return i.snapshot(hands, i.state.targets(team...), actions), nil
+}
+
+// GetSpectatorSnapshot returns the game as seen by someone not playing i.e. without any hands or targets
+func (i *Indigo) GetSpectatorSnapshot() *bg.BoardGameSnapshot {
+	return i.snapshot(make(map[string][]tile), make([]*bg.BoardGameAction, 0), i.visibleActions(""))
+}
 
+func (i *Indigo) snapshot(hands map[string][]tile, targets, actions []*bg.BoardGameAction) *bg.BoardGameSnapshot {
 	return &bg.BoardGameSnapshot{
 		Turn:    i.state.turn,
 		Teams:   i.state.teams,
@@ -148,26 +218,56 @@ func (i *Indigo) GetSnapshot(team ...string) (*bg.BoardGameSnapshot, error) {
 			Round:          i.state.round,
 			RoundsUntilEnd: i.state.roundsUntilEnd,
 			Variant:        i.state.variant,
+			Results:        i.state.results,
 		},
-		Targets: i.state.targets(),
-		Actions: i.actions,
+		Targets: targets,
+		Actions: actions,
 		Message: i.state.message(),
-	}, nil
+	}
+}
+
+// visibleActions returns the actions without the tile rotations of teams other than team as those reveal their hands
+func (i *Indigo) visibleActions(team string) []*bg.BoardGameAction {
+	actions := make([]*bg.BoardGameAction, 0, len(i.actions))
+	for _, action := range i.actions {
+		if action.ActionType != ActionRotateTileClockwise || action.Team == team {
+			actions = append(actions, action)
+		}
+	}
+	return actions
 }
 
 func (i *Indigo) GetBGN() *bgn.Game {
 	tags := map[string]string{
-		"Game":  key,
-		"Teams": strings.Join(i.state.teams, ", "),
-		"Seed":  fmt.Sprintf("%d", i.options.Seed),
+		"Game":           key,
+		"Teams":          strings.Join(i.state.teams, ", "),
+		"Seed":           fmt.Sprintf("%d", i.options.Seed),
+		"Variant":        i.options.Variant,
+		"RoundsUntilEnd": fmt.Sprintf("%d", i.options.RoundsUntilEnd),
 	}
-	actions := make([]bgn.Action, 0)
-	for _, action := range i.actions {
+	// the salt only exists once the seed has been committed to and is kept so loading gives the same commitment
+	if i.options.Salt != "" {
+		tags["SeedSalt"] = i.options.Salt
+	}
+	return &bgn.Game{
+		Tags:    tags,
+		Actions: i.encodeActions(i.actions),
+	}
+}
+
+// encodeActions converts the actions to BGN
+func (i *Indigo) encodeActions(actions []*bg.BoardGameAction) []bgn.Action {
+	encoded := make([]bgn.Action, 0, len(actions))
+	for _, action := range actions {
 		bgnAction := bgn.Action{
 			TeamIndex: indexOf(i.state.teams, action.Team),
 			ActionKey: rune(actionToNotation[action.ActionType][0]),
 		}
 		switch action.ActionType {
+		case ActionRotateTileClockwise:
+			var details RotateTileActionDetails
+			_ = mapstructure.Decode(action.MoreDetails, &details)
+			bgnAction.Details = details.encodeBGN()
 		case ActionPlaceTile:
 			var details PlaceTileActionDetails
 			_ = mapstructure.Decode(action.MoreDetails, &details)
@@ -177,10 +277,24 @@ func (i *Indigo) GetBGN() *bgn.Game {
 			_ = mapstructure.Decode(action.MoreDetails, &details)
 			bgnAction.Details, _ = details.EncodeBGN(i.state.teams)
 		}
-		actions = append(actions, bgnAction)
+		encoded = append(encoded, bgnAction)
 	}
-	return &bgn.Game{
-		Tags:    tags,
-		Actions: actions,
+	return encoded
+}
+
+// Clone returns a deep copy of the game that can be played independently of the original
+func (i *Indigo) Clone() *Indigo {
+	actions := make([]*bg.BoardGameAction, 0, len(i.actions))
+	for _, action := range i.actions {
+		copied := *action
+		actions = append(actions, &copied)
+	}
+	options := *i.options
+	return &Indigo{
+		state:   i.state.clone(),
+		actions: actions,
+		options: &options,
+		undos:   append(make([]*checkpoint, 0), i.undos...),
+		redos:   append(make([]*checkpoint, 0), i.redos...),
 	}
 }
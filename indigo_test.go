@@ -0,0 +1,318 @@
+package go_indigo
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+var benchmarkOptions = &bg.BoardGameOptions{
+	Teams:       []string{"red", "blue", "green"},
+	MoreOptions: IndigoMoreOptions{Seed: 7},
+}
+
+// fullGame plays a game with benchmarkOptions until there are winners
+func fullGame(b *testing.B) *Indigo {
+	game, err := NewIndigo(benchmarkOptions)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for len(game.state.winners) == 0 {
+		playPlaceTile(b, game)
+	}
+	return game
+}
+
+func Test_SnapshotHidesHands(t *testing.T) {
+	teams := []string{"red", "blue", "green"}
+	game, err := NewIndigo(&bg.BoardGameOptions{Teams: teams, MoreOptions: IndigoMoreOptions{Seed: 9, Variant: VariantLargeHands}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 8; i++ {
+		hand := game.state.hands[game.state.turn].GetItems()
+		if err := game.Do(&bg.BoardGameAction{
+			Team:        game.state.turn,
+			ActionType:  ActionRotateTileClockwise,
+			MoreDetails: RotateTileActionDetails{Tile: hand[i%len(hand)].Paths},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		playPlaceTile(t, game)
+	}
+
+	marshal := func(snapshot *bg.BoardGameSnapshot) []byte {
+		raw, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	for _, team := range teams {
+		snapshot, err := game.GetSnapshot(team)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hands := snapshot.MoreData.(IndigoSnapshotData).Hands; len(hands) != 1 || hands[team] == nil {
+			t.Errorf("%s can see hands %v", team, hands)
+		}
+		for _, target := range snapshot.Targets.([]*bg.BoardGameAction) {
+			if target.Team != team {
+				t.Errorf("%s can see a %s target of %s", team, target.ActionType, target.Team)
+			}
+		}
+		for _, action := range snapshot.Actions {
+			if action.ActionType == ActionRotateTileClockwise && action.Team != team {
+				t.Errorf("%s can see a tile rotated by %s", team, action.Team)
+			}
+		}
+
+		// dealing everything team cannot see differently must not change what team sees
+		determinized, err := game.Determinize(team, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed := false
+		for _, other := range teams {
+			if other != team && !reflect.DeepEqual(game.state.hands[other].GetItems(), determinized.state.hands[other].GetItems()) {
+				changed = true
+			}
+		}
+		if !changed {
+			t.Fatalf("determinizing for %s did not change any other hand", team)
+		}
+		other, err := determinized.GetSnapshot(team)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(marshal(snapshot), marshal(other)) {
+			t.Errorf("%s's snapshot depends on tiles %s cannot see", team, team)
+		}
+	}
+
+	spectator := game.GetSpectatorSnapshot()
+	if hands := spectator.MoreData.(IndigoSnapshotData).Hands; len(hands) != 0 || len(spectator.Targets.([]*bg.BoardGameAction)) != 0 {
+		t.Errorf("spectator can see hands %v and targets %v", hands, spectator.Targets)
+	}
+	for _, action := range spectator.Actions {
+		if action.ActionType == ActionRotateTileClockwise {
+			t.Errorf("spectator can see a tile rotated by %s", action.Team)
+		}
+	}
+	determinized, err := game.Determinize(teams[0], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	determinized.state.hands[teams[0]] = game.state.hands[teams[1]].Clone()
+	if !bytes.Equal(marshal(spectator), marshal(determinized.GetSpectatorSnapshot())) {
+		t.Error("spectator snapshot depends on the hands")
+	}
+}
+
+func Test_DeterministicSnapshots(t *testing.T) {
+	play := func() []byte {
+		game, err := NewIndigo(&bg.BoardGameOptions{
+			Teams:       []string{"red", "blue", "green", "yellow"},
+			MoreOptions: IndigoMoreOptions{Seed: 4, RoundsUntilEnd: 3},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for idx, gateway := range game.state.board.gateways {
+			if gateway.ID != idx || gateway.Edges != gatewayEdges[idx] {
+				t.Fatalf("gateway %d has id %d and edges %s", idx, gateway.ID, gateway.Edges)
+			}
+		}
+		for len(game.state.winners) == 0 {
+			playPlaceTile(t, game)
+		}
+		for idx := 1; idx < len(game.state.winners); idx++ {
+			if indexOf(game.state.teams, game.state.winners[idx-1]) > indexOf(game.state.teams, game.state.winners[idx]) {
+				t.Fatalf("winners %v not in the order of teams %v", game.state.winners, game.state.teams)
+			}
+		}
+		snapshot, err := game.GetSnapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	first := play()
+	for i := 0; i < 10; i++ {
+		if !bytes.Equal(play(), first) {
+			t.Fatal("the same game produced different snapshots")
+		}
+	}
+}
+
+func Test_Clone(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 7},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 8; i++ {
+		playPlaceTile(t, game)
+	}
+	_ = game.Undo(1)
+
+	before := dump(t, game)
+	clone := game.Clone()
+	if dump(t, clone) != before {
+		t.Fatal("clone does not match the original game")
+	}
+	for i := 0; i < 8; i++ {
+		playPlaceTile(t, clone)
+	}
+	_ = clone.Undo(3)
+	if dump(t, game) != before {
+		t.Fatal("playing the clone changed the original game")
+	}
+	if err := game.Redo(1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkInvariants fails the test if anything that must hold after every tile placed does not
+func checkInvariants(t *testing.T, before, after *state) {
+	colors := map[string]int{}
+	points, gemsCount := map[string]int{}, map[string]int{}
+	for _, gem := range after.board.gems {
+		colors[gem.Color]++
+		if gem.gateway >= 0 {
+			for _, team := range after.board.gateways[gem.gateway].Teams {
+				points[team] += colorToPoints[gem.Color]
+				gemsCount[team]++
+			}
+		}
+	}
+	if colors[Amber] != 6 || colors[Emerald] != 5 || colors[Sapphire] != 1 {
+		t.Fatalf("gems not conserved %v", colors)
+	}
+	for _, team := range after.teams {
+		if after.points[team] != points[team] || after.gemsCount[team] != gemsCount[team] {
+			t.Fatalf("%s has %d points and %d gems but scored gems are worth %d points and %d gems",
+				team, after.points[team], after.gemsCount[team], points[team], gemsCount[team])
+		}
+	}
+
+	placed, total := 0, len(after.deck.GetItems())
+	for _, hand := range after.hands {
+		total += len(hand.GetItems())
+	}
+	for r, row := range after.board.rows() {
+		for c, tile := range row {
+			if tile != nil {
+				total++
+			}
+			previous := before.board.tileAt(r, c)
+			if previous == nil && tile != nil {
+				placed++
+			} else if previous != nil && (tile == nil || tile.Paths != previous.Paths) {
+				t.Fatalf("tile at (%d, %d) was overwritten", r, c)
+			}
+		}
+	}
+	if placed != 1 {
+		t.Fatalf("%d tiles placed but expected 1", placed)
+	}
+	if expected := 54 + len(initTreasureTiles); total != expected {
+		t.Fatalf("%d tiles in the deck, hands and board but expected %d", total, expected)
+	}
+}
+
+func FuzzPlay(f *testing.F) {
+	for seed := int64(0); seed < 6; seed++ {
+		f.Add(seed, uint8(seed))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, setup uint8) {
+		teams := []string{"red", "blue", "green", "yellow"}[:2+int(setup)%3]
+		variant := variants[int(setup)/3%len(variants)]
+		game, err := NewIndigo(&bg.BoardGameOptions{
+			Teams:       teams,
+			MoreOptions: IndigoMoreOptions{Seed: seed, Variant: variant},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := rand.New(rand.NewSource(seed))
+		for len(game.state.winners) == 0 {
+			before := game.state.clone()
+			if hand := game.state.hands[game.state.turn].GetItems(); len(hand) > 0 && r.Intn(2) == 0 {
+				if err := game.Do(&bg.BoardGameAction{
+					Team:        game.state.turn,
+					ActionType:  ActionRotateTileClockwise,
+					MoreDetails: RotateTileActionDetails{Tile: hand[r.Intn(len(hand))].Paths},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			moves := game.state.legalMoves()
+			if len(moves) == 0 {
+				t.Fatalf("%s cannot move", game.state.turn)
+			}
+			if err := game.Do(moves[r.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+			checkInvariants(t, before, game.state)
+		}
+	})
+}
+
+func BenchmarkNewIndigo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := NewIndigo(benchmarkOptions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDoPlaceTile(b *testing.B) {
+	actions := fullGame(b).actions
+	var game *Indigo
+	for i, idx := 0, len(actions); i < b.N; i, idx = i+1, idx+1 {
+		if idx == len(actions) {
+			b.StopTimer()
+			game, _ = NewIndigo(benchmarkOptions)
+			idx = 0
+			b.StartTimer()
+		}
+		if err := game.Do(actions[idx]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetSnapshot(b *testing.B) {
+	game, err := NewIndigo(benchmarkOptions)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 15; i++ {
+		playPlaceTile(b, game)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := game.GetSnapshot("red"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetBGN(b *testing.B) {
+	game := fullGame(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.GetBGN()
+	}
+}
@@ -0,0 +1,309 @@
+package go_indigo
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+	cl "github.com/quibbble/go-boardgame/pkg/collection"
+)
+
+// jsonVersion is the version of the saved game format written by MarshalJSON
+const jsonVersion = 2
+
+// indigoJSON is the complete game as saved by MarshalJSON
+type indigoJSON struct {
+	Version        int
+	Options        IndigoMoreOptions
+	Turn           string
+	Teams          []string
+	Winners        []string
+	Board          boardJSON
+	Deck           []tile
+	Hands          map[string][]tile
+	Variant        string
+	Points         map[string]int
+	GemsCount      map[string]int
+	Round          int
+	RoundsUntilEnd int
+	Placed         int
+	Results        []*PlaceTileResult
+	Actions        []*bg.BoardGameAction
+}
+
+type boardJSON struct {
+	Tiles    [][]*tile
+	Gateways []*gateway
+	Gems     []gemJSON
+}
+
+type gemJSON struct {
+	Color       string
+	Edge        string
+	Row, Column int
+	Ply         int
+	Collided    bool
+	Gateway     int // the index of the gateway the gem reached or -1 if it has not reached one
+}
+
+// MarshalJSON saves the complete game so it can be restored exactly with UnmarshalJSON without replaying its actions
+// NOTE - the actions that can be undone or redone and any observers are not saved
+func (i *Indigo) MarshalJSON() ([]byte, error) {
+	s := i.state
+	hands := make(map[string][]tile)
+	for team, hand := range s.hands {
+		hands[team] = hand.GetItems()
+	}
+	gems := make([]gemJSON, 0, len(s.board.gems))
+	for _, gem := range s.board.gems {
+		row, col, edge := gem.location()
+		gems = append(gems, gemJSON{
+			Color:    gem.Color,
+			Edge:     edge,
+			Row:      row,
+			Column:   col,
+			Ply:      gem.Ply,
+			Collided: gem.collided,
+			Gateway:  gem.gateway,
+		})
+	}
+	return json.Marshal(indigoJSON{
+		Version: jsonVersion,
+		Options: *i.options,
+		Turn:    s.turn,
+		Teams:   s.teams,
+		Winners: s.winners,
+		Board: boardJSON{
+			Tiles:    s.board.rows(),
+			Gateways: s.board.gatewayList(),
+			Gems:     gems,
+		},
+		Deck:           s.deck.GetItems(),
+		Hands:          hands,
+		Variant:        s.variant,
+		Points:         s.points,
+		GemsCount:      s.gemsCount,
+		Round:          s.round,
+		RoundsUntilEnd: s.roundsUntilEnd,
+		Placed:         s.placed,
+		Results:        s.results,
+		Actions:        i.actions,
+	})
+}
+
+// UnmarshalJSON restores a game saved with MarshalJSON
+func (i *Indigo) UnmarshalJSON(data []byte) error {
+	var saved indigoJSON
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return errSavedGame(err)
+	}
+	if err := saved.validate(); err != nil {
+		return errSavedGame(err)
+	}
+
+	// the gateways and treasure tiles were checked to match a new board so only the placed tiles and gems are restored
+	board := newBoard(saved.Teams)
+	for r, row := range saved.Board.Tiles {
+		for c, t := range row {
+			if t != nil && !t.Treasure {
+				board.setTile(cellIndex(r, c), t)
+			}
+		}
+	}
+	for idx, g := range saved.Board.Gems {
+		gem := newGem(idx, g.Color, g.Edge, g.Row, g.Column)
+		gem.Ply = g.Ply
+		gem.collided = g.Collided
+		gem.gateway = g.Gateway
+		board.gems[idx] = *gem
+	}
+	deck := cl.NewCollection[tile](saved.Options.Seed)
+	deck.Add(saved.Deck...)
+	hands := make(map[string]*cl.Collection[tile])
+	for _, team := range saved.Teams {
+		hand := cl.NewCollection[tile](0)
+		hand.Add(saved.Hands[team]...)
+		hands[team] = hand
+	}
+	results := saved.Results
+	if results == nil {
+		results = make([]*PlaceTileResult, 0)
+	}
+	actions := make([]*bg.BoardGameAction, 0, len(saved.Actions))
+	for _, action := range saved.Actions {
+		details, err := decodeActionDetails(action)
+		if err != nil {
+			return errSavedGame(err)
+		}
+		action.MoreDetails = details
+		actions = append(actions, action)
+	}
+
+	i.state = &state{
+		turn:           saved.Turn,
+		teams:          saved.Teams,
+		winners:        append(make([]string, 0), saved.Winners...),
+		board:          board,
+		deck:           deck,
+		hands:          hands,
+		variant:        saved.Variant,
+		points:         saved.Points,
+		gemsCount:      saved.GemsCount,
+		round:          saved.Round,
+		roundsUntilEnd: saved.RoundsUntilEnd,
+		placed:         saved.Placed,
+		results:        results,
+	}
+	i.state.hash = i.state.zobrist()
+	i.actions = actions
+	i.options = &saved.Options
+	i.undos = make([]*checkpoint, 0)
+	i.redos = make([]*checkpoint, 0)
+	i.observers = nil
+	return nil
+}
+
+// validate checks that the saved game is one that could have been reached by playing so it can be restored safely
+func (saved *indigoJSON) validate() error {
+	if saved.Version != jsonVersion {
+		return fmt.Errorf("unsupported saved game version %d", saved.Version)
+	}
+	if len(saved.Teams) < minTeams || len(saved.Teams) > maxTeams {
+		return fmt.Errorf("saved game has %d teams", len(saved.Teams))
+	}
+	if duplicates(saved.Teams) {
+		return fmt.Errorf("saved game has duplicate teams")
+	}
+	if !contains(variants, saved.Variant) || saved.Options.Variant != saved.Variant {
+		return fmt.Errorf("saved game has invalid variant %s", saved.Variant)
+	}
+	if !contains(saved.Teams, saved.Turn) {
+		return fmt.Errorf("saved turn %s is not a team", saved.Turn)
+	}
+	for _, winner := range saved.Winners {
+		if !contains(saved.Teams, winner) {
+			return fmt.Errorf("saved winner %s is not a team", winner)
+		}
+	}
+	if saved.Round < 0 || saved.RoundsUntilEnd <= 0 {
+		return fmt.Errorf("saved game has invalid round %d of %d", saved.Round, saved.RoundsUntilEnd)
+	}
+	if saved.Placed < 0 || saved.Placed > numCells {
+		return fmt.Errorf("saved game has %d tiles placed", saved.Placed)
+	}
+	for name, counts := range map[string]map[string]int{"points": saved.Points, "gems count": saved.GemsCount} {
+		if len(counts) != len(saved.Teams) {
+			return fmt.Errorf("saved %s has %d teams", name, len(counts))
+		}
+		for _, team := range saved.Teams {
+			if _, ok := counts[team]; !ok {
+				return fmt.Errorf("saved %s missing team %s", name, team)
+			}
+		}
+	}
+	if len(saved.Hands) != len(saved.Teams) {
+		return fmt.Errorf("saved hands have %d teams", len(saved.Hands))
+	}
+	for _, team := range saved.Teams {
+		hand, ok := saved.Hands[team]
+		if !ok {
+			return fmt.Errorf("saved hands missing team %s", team)
+		}
+		for _, t := range hand {
+			if _, err := newTile(t.Paths); err != nil || t.Treasure {
+				return fmt.Errorf("saved hand of %s has invalid tile %s", team, t.Paths)
+			}
+		}
+	}
+	for _, t := range saved.Deck {
+		if _, err := newTile(t.Paths); err != nil || t.Treasure {
+			return fmt.Errorf("saved deck has invalid tile %s", t.Paths)
+		}
+	}
+
+	// the board must match a new board apart from the placed tiles and the gems
+	initial := newBoard(saved.Teams)
+	if len(saved.Board.Tiles) != rows {
+		return fmt.Errorf("saved board has %d rows", len(saved.Board.Tiles))
+	}
+	for r, row := range saved.Board.Tiles {
+		if len(row) != rowLength(r) {
+			return fmt.Errorf("saved board row %d has %d columns", r, len(row))
+		}
+		for c, t := range row {
+			if existing := initial.tileAt(r, c); existing != nil {
+				if t == nil || *t != *existing {
+					return fmt.Errorf("saved board missing treasure tile at (%d, %d)", r, c)
+				}
+			} else if t != nil {
+				if _, err := newTile(t.Paths); err != nil || t.Treasure {
+					return fmt.Errorf("saved board has invalid tile %s at (%d, %d)", t.Paths, r, c)
+				}
+			}
+		}
+	}
+	if len(saved.Board.Gateways) != len(initial.gateways) {
+		return fmt.Errorf("saved board has %d gateways", len(saved.Board.Gateways))
+	}
+	for idx, gateway := range saved.Board.Gateways {
+		if gateway == nil || gateway.Edges != initial.gateways[idx].Edges || gateway.Locations != initial.gateways[idx].Locations ||
+			!reflect.DeepEqual(gateway.Teams, initial.gateways[idx].Teams) {
+			return fmt.Errorf("saved board gateway %d does not match the board", idx)
+		}
+	}
+	if len(saved.Board.Gems) != len(initial.gems) {
+		return fmt.Errorf("saved board has %d gems", len(saved.Board.Gems))
+	}
+	for idx, g := range saved.Board.Gems {
+		if g.Color != initial.gems[idx].Color {
+			return fmt.Errorf("saved gem %d has color %s", idx, g.Color)
+		}
+		if g.Row < 0 || g.Row >= rows || g.Column < 0 || g.Column >= rowLength(g.Row) || saved.Board.Tiles[g.Row][g.Column] == nil ||
+			!(contains([]string{A, B, C, D, E, F}, g.Edge) || g.Edge == Special && saved.Board.Tiles[g.Row][g.Column].Paths == Special) {
+			return fmt.Errorf("saved gem %d is not on a tile edge at %s (%d, %d)", idx, g.Edge, g.Row, g.Column)
+		}
+		if g.Ply < 0 || g.Ply > saved.Placed || g.Gateway < -1 || g.Gateway >= len(saved.Board.Gateways) {
+			return fmt.Errorf("saved gem %d has invalid ply %d or gateway %d", idx, g.Ply, g.Gateway)
+		}
+	}
+	for _, result := range saved.Results {
+		if result == nil {
+			return fmt.Errorf("saved result is missing")
+		}
+	}
+	for _, action := range saved.Actions {
+		if action == nil || !contains(saved.Teams, action.Team) {
+			return fmt.Errorf("saved action is not by a team")
+		}
+	}
+	return nil
+}
+
+func errSavedGame(err error) error {
+	return &bgerr.Error{
+		Err:    err,
+		Status: bgerr.StatusInvalidOption,
+	}
+}
+
+// decodeActionDetails converts the generic details of a saved action back into the details type of its action type
+func decodeActionDetails(action *bg.BoardGameAction) (interface{}, error) {
+	switch action.ActionType {
+	case ActionRotateTileClockwise:
+		var details RotateTileActionDetails
+		err := mapstructure.Decode(action.MoreDetails, &details)
+		return details, err
+	case ActionPlaceTile:
+		var details PlaceTileActionDetails
+		err := mapstructure.Decode(action.MoreDetails, &details)
+		return details, err
+	case bg.ActionSetWinners:
+		var details bg.SetWinnersActionDetails
+		err := mapstructure.Decode(action.MoreDetails, &details)
+		return details, err
+	}
+	return nil, fmt.Errorf("saved action has unknown action type %s", action.ActionType)
+}
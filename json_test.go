@@ -0,0 +1,124 @@
+package go_indigo
+
+import (
+	"encoding/json"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+)
+
+func Test_JSONRoundTrip(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue", "green"},
+		MoreOptions: IndigoMoreOptions{Seed: 17, Variant: VariantLargeHands},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(game.state.winners) == 0 {
+		raw, err := json.Marshal(game)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var restored Indigo
+		if err := json.Unmarshal(raw, &restored); err != nil {
+			t.Fatal(err)
+		}
+		if dump(t, &restored) != dump(t, game) {
+			t.Fatal("restored game does not match the original game")
+		}
+
+		// both games must keep playing the same way
+		move := game.state.legalMoves()[0]
+		if err := game.Do(move); err != nil {
+			t.Fatal(err)
+		}
+		if err := restored.Do(move); err != nil {
+			t.Fatal(err)
+		}
+		if dump(t, &restored) != dump(t, game) {
+			t.Fatal("restored game diverged from the original game")
+		}
+		if restored.GetBGN().String() == "" {
+			t.Fatal("restored game has no bgn")
+		}
+	}
+}
+
+func Test_JSONMalformed(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		playPlaceTile(t, game)
+	}
+	raw, err := json.Marshal(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]func(saved map[string]interface{}){
+		"missing points":     func(saved map[string]interface{}) { delete(saved, "Points") },
+		"missing gems count": func(saved map[string]interface{}) { saved["GemsCount"] = map[string]int{"red": 0} },
+		"unknown turn":       func(saved map[string]interface{}) { saved["Turn"] = "green" },
+		"unknown winner":     func(saved map[string]interface{}) { saved["Winners"] = []string{"green"} },
+		"unknown variant":    func(saved map[string]interface{}) { saved["Variant"] = "Huge" },
+		"missing hand":       func(saved map[string]interface{}) { delete(saved["Hands"].(map[string]interface{}), "blue") },
+		"invalid hand tile": func(saved map[string]interface{}) {
+			saved["Hands"].(map[string]interface{})["red"] = []tile{{Paths: "ABCDEA"}}
+		},
+		"invalid deck tile": func(saved map[string]interface{}) { saved["Deck"] = []tile{{Paths: "AB"}} },
+		"invalid board tile": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Tiles"].([]interface{})[0].([]interface{})[0] = tile{Paths: "XYZ"}
+		},
+		"missing treasure tile": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Tiles"].([]interface{})[4].([]interface{})[4] = nil
+		},
+		"short board row": func(saved map[string]interface{}) {
+			tiles := saved["Board"].(map[string]interface{})["Tiles"].([]interface{})
+			tiles[2] = tiles[2].([]interface{})[1:]
+		},
+		"missing gateway": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Gateways"].([]interface{})[1] = nil
+		},
+		"invalid gem edge": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Gems"].([]interface{})[0].(map[string]interface{})["Edge"] = "Q"
+		},
+		"invalid gem row": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Gems"].([]interface{})[0].(map[string]interface{})["Row"] = 12
+		},
+		"invalid gem column": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Gems"].([]interface{})[0].(map[string]interface{})["Column"] = -1
+		},
+		"gem off the tiles": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Gems"].([]interface{})[11].(map[string]interface{})["Row"] = 8
+		},
+		"unknown gem gateway": func(saved map[string]interface{}) {
+			saved["Board"].(map[string]interface{})["Gems"].([]interface{})[0].(map[string]interface{})["Gateway"] = 6
+		},
+		"unknown action team": func(saved map[string]interface{}) {
+			saved["Actions"].([]interface{})[0].(map[string]interface{})["Team"] = "green"
+		},
+	}
+	for name, modify := range tests {
+		var saved map[string]interface{}
+		if err := json.Unmarshal(raw, &saved); err != nil {
+			t.Fatal(err)
+		}
+		modify(saved)
+		malformed, err := json.Marshal(saved)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var restored Indigo
+		err = json.Unmarshal(malformed, &restored)
+		if _, ok := err.(*bgerr.Error); !ok {
+			t.Errorf("%s: expected a bgerr error but got %v", name, err)
+		}
+	}
+}
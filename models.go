@@ -2,8 +2,10 @@ package go_indigo
 
 // Action types
 const (
-	ActionRotateTileClockwise = "RotateTileClockwise" // NOTE - this is not tracked by BGN
+	ActionRotateTileClockwise = "RotateTileClockwise"
 	ActionPlaceTile           = "PlaceTile"
+	ActionUndo                = "Undo" // NOTE - this is not tracked by BGN
+	ActionRedo                = "Redo" // NOTE - this is not tracked by BGN
 )
 
 // Indigo Variants
@@ -18,7 +20,8 @@ var variants = []string{VariantClassic, VariantLargeHands}
 type IndigoMoreOptions struct {
 	Seed           int64
 	Variant        string
-	RoundsUntilEnd int // the number of rounds until the game ends
+	RoundsUntilEnd int    // the number of rounds until the game ends
+	Salt           string // secret mixed with the seed in the commitment of GetPlayerBGN which CommitSeed makes if empty
 }
 
 type IndigoMoreInfo struct {
@@ -34,6 +37,54 @@ type PlaceTileActionDetails struct {
 	Row, Column int
 }
 
+type UndoActionDetails struct {
+	Count int // the number of actions to undo which defaults to 1
+}
+
+type RedoActionDetails struct {
+	Count int // the number of actions to redo which defaults to 1
+}
+
+// PlaceTileResult is everything that happened to the gems when a tile was placed
+type PlaceTileResult struct {
+	Team        string
+	Tile        string
+	Row, Column int
+	Paths       []*GemPath      // the paths of every gem that moved
+	Collisions  []*GemCollision // the gems that collided
+	Scores      []*GemScore     // the gems that reached a gateway
+	Points      map[string]int  // the points gained by each team
+}
+
+// GemPath is the ordered list of locations a gem moved through starting where it was before the tile was placed
+type GemPath struct {
+	Gem   int // the index of the gem in the board's gems
+	Color string
+	Steps []*GemStep
+}
+
+// GemStep is a location on the board a gem moved to
+type GemStep struct {
+	Row, Column int
+	Edge        string
+}
+
+// GemCollision is two gems that collided and were removed from play
+type GemCollision struct {
+	Gems        [2]int // the indexes of the gems in the board's gems
+	Row, Column int
+	Edge        string
+}
+
+// GemScore is a gem that reached a gateway and the teams it scored for
+type GemScore struct {
+	Gem    int // the index of the gem in the board's gems
+	Color  string
+	Edges  string   // the edges of the gateway the gem reached
+	Teams  []string // the teams that own the gateway
+	Points int      // the points gained by each team
+}
+
 // IndigoSnapshotData is the game data unique to Indigo
 type IndigoSnapshotData struct {
 	Board          *board
@@ -42,6 +93,7 @@ type IndigoSnapshotData struct {
 	Round          int
 	RoundsUntilEnd int
 	Variant        string
+	Results        []*PlaceTileResult // what happened to the gems for each tile placed in order
 }
 
 var (
@@ -84,6 +136,9 @@ var (
 		{Sapphire, Special, 4, 4},
 	}
 
+	// edges of every gateway in the order they are created so the index of the edges is the gateway's id
+	gatewayEdges = []string{A + B, B + C, C + D, D + E, E + F, F + A}
+
 	// map from edges to (row, col) locations of every gateway
 	initGateways = map[string][3][2]int{
 		A + B: {{0, 1}, {0, 2}, {0, 3}},
@@ -0,0 +1,39 @@
+package go_indigo
+
+import (
+	"fmt"
+
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+)
+
+// Preview returns what would happen if team placed tile at (row, column) without changing the game
+func (i *Indigo) Preview(team, tile string, row, column int) (*PlaceTileResult, error) {
+	if len(i.state.winners) > 0 {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("game already over"),
+			Status: bgerr.StatusGameOver,
+		}
+	}
+	return i.state.clone().placeTile(team, tile, row, column)
+}
+
+// step records gem moving from its current location to (row, column, edge)
+func (r *PlaceTileResult) step(idx int, gem *gem, row, column int, edge string) {
+	var path *GemPath
+	for _, p := range r.Paths {
+		if p.Gem == idx {
+			path = p
+			break
+		}
+	}
+	if path == nil {
+		startRow, startColumn, startEdge := gem.location()
+		path = &GemPath{
+			Gem:   idx,
+			Color: gem.Color,
+			Steps: []*GemStep{{Row: startRow, Column: startColumn, Edge: startEdge}},
+		}
+		r.Paths = append(r.Paths, path)
+	}
+	path.Steps = append(path.Steps, &GemStep{Row: row, Column: column, Edge: edge})
+}
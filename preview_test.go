@@ -0,0 +1,58 @@
+package go_indigo
+
+import (
+	"reflect"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+func Test_Preview(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved := false
+	for len(game.state.winners) == 0 {
+		before := dump(t, game)
+		move := game.state.legalMoves()[0]
+		details := move.MoreDetails.(PlaceTileActionDetails)
+		result, err := game.Preview(move.Team, details.Tile, details.Row, details.Column)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dump(t, game) != before {
+			t.Fatal("preview changed the game")
+		}
+		points := map[string]int{}
+		for team, p := range game.state.points {
+			points[team] = p
+		}
+		if err := game.Do(move); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(game.state.results[len(game.state.results)-1], result) {
+			t.Fatal("placed tile result does not match preview")
+		}
+		for _, path := range result.Paths {
+			moved = true
+			last := path.Steps[len(path.Steps)-1]
+			row, col, edge := game.state.board.gems[path.Gem].location()
+			if row != last.Row || col != last.Column || edge != last.Edge {
+				t.Fatalf("gem %d ended at (%d, %d, %s) but preview expected (%d, %d, %s)",
+					path.Gem, row, col, edge, last.Row, last.Column, last.Edge)
+			}
+		}
+		for team, p := range result.Points {
+			if game.state.points[team]-points[team] != p {
+				t.Fatalf("%s gained %d points but preview expected %d", team, game.state.points[team]-points[team], p)
+			}
+		}
+	}
+	if !moved {
+		t.Fatal("no gems moved during the game")
+	}
+}
@@ -0,0 +1,211 @@
+// Package sim plays many games of Indigo between bots and reports statistics about them
+package sim
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	bg "github.com/quibbble/go-boardgame"
+	indigo "github.com/quibbble/go-indigo"
+	"github.com/quibbble/go-indigo/bot"
+)
+
+// colors are the gem colors in the order they are reported
+var colors = []string{indigo.Amber, indigo.Emerald, indigo.Sapphire}
+
+// Config describes the games to simulate
+type Config struct {
+	Games          int   // the number of games to play
+	Seed           int64 // the seed of the first game with each following game using the next seed
+	Teams          int   // the number of teams in each game
+	Variant        string
+	RoundsUntilEnd int
+	Workers        int // the number of games played at once which defaults to 1
+
+	// Players creates the player for a seat in a game given a seed unique to that seat and game
+	Players func(seat int, seed int64) (bot.Player, error)
+}
+
+// Report is the combined statistics of every simulated game
+type Report struct {
+	Games         int
+	Wins          []int              // the number of games each seat won including ties
+	WinRates      []float64          // the fraction of games each seat won including ties
+	AveragePoints []float64          // the average points each seat ended with
+	GemsScored    map[string]float64 // the average number of gems of each color scored per game
+	Collisions    float64            // the average number of gem collisions per game
+	Rounds        float64            // the average number of rounds played per game counting a final round only partly played
+	TilesPlaced   float64            // the average number of tiles placed per game
+}
+
+// result is the statistics of a single game
+type result struct {
+	winners    []int
+	points     []int
+	gemsScored map[string]int
+	collisions int
+	rounds     int
+	actions    int
+}
+
+// Run plays every game described by config and reports the combined statistics
+func Run(config Config) (*Report, error) {
+	if config.Games <= 0 {
+		return nil, fmt.Errorf("at least one game must be played")
+	}
+	if config.Players == nil {
+		return nil, fmt.Errorf("players are required")
+	}
+	workers := config.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	results := make([]*result, config.Games)
+	errs := make([]error, config.Games)
+	games := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range games {
+				results[idx], errs[idx] = play(config, config.Seed+int64(idx))
+			}
+		}()
+	}
+	for idx := 0; idx < config.Games; idx++ {
+		games <- idx
+	}
+	close(games)
+	wg.Wait()
+
+	report := &Report{
+		Games:         config.Games,
+		Wins:          make([]int, config.Teams),
+		WinRates:      make([]float64, config.Teams),
+		AveragePoints: make([]float64, config.Teams),
+		GemsScored:    make(map[string]float64),
+	}
+	for idx, r := range results {
+		if errs[idx] != nil {
+			return nil, fmt.Errorf("game %d: %w", idx, errs[idx])
+		}
+		for _, seat := range r.winners {
+			report.Wins[seat]++
+		}
+		for seat, points := range r.points {
+			report.AveragePoints[seat] += float64(points)
+		}
+		for color, count := range r.gemsScored {
+			report.GemsScored[color] += float64(count)
+		}
+		report.Collisions += float64(r.collisions)
+		report.Rounds += float64(r.rounds)
+		report.TilesPlaced += float64(r.actions)
+	}
+	games64 := float64(config.Games)
+	for seat := range report.Wins {
+		report.WinRates[seat] = float64(report.Wins[seat]) / games64
+		report.AveragePoints[seat] /= games64
+	}
+	for _, color := range colors {
+		report.GemsScored[color] /= games64
+	}
+	report.Collisions /= games64
+	report.Rounds /= games64
+	report.TilesPlaced /= games64
+	return report, nil
+}
+
+// play plays a single game to the end
+func play(config Config, seed int64) (*result, error) {
+	teams := make([]string, 0)
+	players := make(map[string]bot.Player)
+	for seat := 0; seat < config.Teams; seat++ {
+		team := fmt.Sprintf("seat%d", seat+1)
+		player, err := config.Players(seat, seed*int64(config.Teams)+int64(seat))
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+		players[team] = player
+	}
+	game, err := indigo.NewIndigo(&bg.BoardGameOptions{
+		Teams: teams,
+		MoreOptions: indigo.IndigoMoreOptions{
+			Seed:           seed,
+			Variant:        config.Variant,
+			RoundsUntilEnd: config.RoundsUntilEnd,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		snapshot, err := game.GetSnapshot()
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshot.Winners) > 0 {
+			break
+		}
+		action, err := players[snapshot.Turn].Action(game, snapshot.Turn)
+		if err != nil {
+			return nil, err
+		}
+		if err := game.Do(action); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot, _ := game.GetSnapshot()
+	data := snapshot.MoreData.(indigo.IndigoSnapshotData)
+	r := &result{
+		winners:    make([]int, 0),
+		points:     make([]int, 0),
+		gemsScored: make(map[string]int),
+		rounds:     data.Round,
+		actions:    len(data.Results),
+	}
+	// a game that ends when the gems run out can stop before the turn is back at the first team
+	if snapshot.Turn != teams[0] {
+		r.rounds++
+	}
+	for _, winner := range snapshot.Winners {
+		for seat, team := range teams {
+			if team == winner {
+				r.winners = append(r.winners, seat)
+			}
+		}
+	}
+	for _, team := range teams {
+		r.points = append(r.points, data.Points[team])
+	}
+	for _, placed := range data.Results {
+		for _, score := range placed.Scores {
+			r.gemsScored[score.Color]++
+		}
+		r.collisions += len(placed.Collisions)
+	}
+	return r, nil
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("games: %d\n", r.Games))
+	sb.WriteString("seat  wins  win rate  avg points\n")
+	for seat := range r.Wins {
+		sb.WriteString(fmt.Sprintf("%-4d  %4d  %8.3f  %10.2f\n", seat+1, r.Wins[seat], r.WinRates[seat], r.AveragePoints[seat]))
+	}
+	sb.WriteString("avg gems scored per game:")
+	for _, color := range colors {
+		sb.WriteString(fmt.Sprintf(" %s %.2f", strings.ToLower(color), r.GemsScored[color]))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("avg collisions per game: %.2f\n", r.Collisions))
+	sb.WriteString(fmt.Sprintf("avg rounds per game: %.2f\n", r.Rounds))
+	sb.WriteString(fmt.Sprintf("avg tiles placed per game: %.2f\n", r.TilesPlaced))
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/quibbble/go-indigo/bot"
+)
+
+func Test_Run(t *testing.T) {
+	config := Config{
+		Games:          8,
+		Seed:           1,
+		Teams:          3,
+		RoundsUntilEnd: 10,
+		Players: func(seat int, seed int64) (bot.Player, error) {
+			return bot.NewRandom(seed), nil
+		},
+	}
+	serial, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Workers = 4
+	parallel, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Fatal("parallel simulation did not match serial simulation")
+	}
+	wins := 0
+	for _, w := range serial.Wins {
+		wins += w
+	}
+	if wins < serial.Games {
+		t.Fatalf("expected at least %d wins but got %d", serial.Games, wins)
+	}
+	if serial.Rounds != 10 {
+		t.Fatalf("expected 10 rounds per game but got %f", serial.Rounds)
+	}
+}
+
+func Test_PartialRound(t *testing.T) {
+	config := Config{
+		Teams: 3,
+		Players: func(seat int, seed int64) (bot.Player, error) {
+			return bot.NewRandom(seed), nil
+		},
+	}
+	partial := false
+	for seed := int64(0); seed < 10; seed++ {
+		r, err := play(config, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// every round has at most one placement per team so the rounds must cover every placement
+		if r.rounds*config.Teams < r.actions {
+			t.Fatalf("%d rounds counted for %d tiles placed by %d teams with seed %d", r.rounds, r.actions, config.Teams, seed)
+		}
+		partial = partial || r.actions%config.Teams != 0
+	}
+	if !partial {
+		t.Fatal("expected some games to end part way through a round")
+	}
+}
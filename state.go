@@ -20,6 +20,10 @@ type state struct {
 	points                map[string]int
 	gemsCount             map[string]int
 	round, roundsUntilEnd int
+	placed                int                // the number of tiles placed which is the ply of the latest placement
+	results               []*PlaceTileResult // what happened to the gems each time a tile was placed
+	notify                func(event Event)  // sends events to observers and is never copied by clone
+	hash                  uint64             // zobrist hash of the position updated as tiles are placed
 }
 
 func newState(teams []string, random int64, variant string, roundsUntilEnd int) (*state, error) {
@@ -66,7 +70,7 @@ func newState(teams []string, random int64, variant string, roundsUntilEnd int)
 		}
 	}
 
-	return &state{
+	s := &state{
 		turn:           teams[0],
 		teams:          teams,
 		winners:        make([]string, 0),
@@ -78,7 +82,10 @@ func newState(teams []string, random int64, variant string, roundsUntilEnd int)
 		gemsCount:      gemsCount,
 		round:          0,
 		roundsUntilEnd: roundsUntilEnd,
-	}, nil
+		results:        make([]*PlaceTileResult, 0),
+	}
+	s.hash = s.zobrist()
+	return s, nil
 }
 
 func (s *state) rotateTileClockwise(team, paths string) error {
@@ -104,19 +111,20 @@ func (s *state) rotateTileClockwise(team, paths string) error {
 	}
 	tile, _ := s.hands[team].GetItem(idx)
 	tile.RotateClockwise()
+	s.emit(TileRotatedEvent{Team: team})
 	return nil
 }
 
-func (s *state) placeTile(team, paths string, row, col int) error {
+func (s *state) placeTile(team, paths string, row, col int) (*PlaceTileResult, error) {
 	if team != s.turn {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    fmt.Errorf("%s cannot play on %s turn", team, s.turn),
 			Status: bgerr.StatusWrongTurn,
 		}
 	}
 	t, err := newTile(paths)
 	if err != nil {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidActionDetails,
 		}
@@ -125,61 +133,112 @@ func (s *state) placeTile(team, paths string, row, col int) error {
 	// place tile and remove it from your hand
 	tileIdx := s.hands[team].IndexOf(*t, func(a, b tile) bool { return a.equals(&b) })
 	if tileIdx < 0 {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    fmt.Errorf("%s's hand does not contain %s", team, paths),
 			Status: bgerr.StatusInvalidAction,
 		}
 	}
 	if err := s.board.place(t, row, col); err != nil {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidActionDetails,
 		}
 	}
 	_ = s.hands[team].Remove(tileIdx)
 
+	result := &PlaceTileResult{
+		Team:       team,
+		Tile:       t.Paths,
+		Row:        row,
+		Column:     col,
+		Paths:      make([]*GemPath, 0),
+		Collisions: make([]*GemCollision, 0),
+		Scores:     make([]*GemScore, 0),
+		Points:     make(map[string]int),
+	}
+	for _, team := range s.teams {
+		result.Points[team] = 0
+	}
+
+	// update the hash with the placed tile
+	if id := t.id(); id >= 0 {
+		s.hash ^= zobristTiles[cellIndex(row, col)][id]
+	}
+
 	// update gem locations
-	movedGems, err := s.board.moveGems(row, col)
+	movedGems, err := s.board.moveGems(row, col, result)
 	if err != nil {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidActionDetails,
 		}
 	}
 
+	// record when gems were removed from play
+	for idx := range s.board.gems {
+		if gem := &s.board.gems[idx]; gem.Ply == 0 && gem.Status() != GemInPlay {
+			gem.Ply = s.placed + 1
+		}
+	}
+
 	// update scores based on new gem locations
 	for _, gem := range movedGems {
-		if gem.gateway != nil {
-			for _, team := range gem.gateway.Teams {
+		if gem.gateway >= 0 {
+			gateway := &s.board.gateways[gem.gateway]
+			for _, team := range gateway.Teams {
 				s.points[team] += colorToPoints[gem.Color]
 				s.gemsCount[team] += 1
+				result.Points[team] += colorToPoints[gem.Color]
 			}
+			result.Scores = append(result.Scores, &GemScore{
+				Gem:    gem.ID,
+				Color:  gem.Color,
+				Edges:  gateway.Edges,
+				Teams:  append(make([]string, 0), gateway.Teams...),
+				Points: colorToPoints[gem.Color],
+			})
 		}
 	}
 
+	s.updateGemKeys(result)
+
+	s.placed++
+	s.results = append(s.results, result)
+
+	s.emit(TilePlacedEvent{Team: team, Tile: t.Paths, Row: row, Column: col})
+	for _, path := range result.Paths {
+		s.emit(GemMovedEvent{*path})
+	}
+	for _, collision := range result.Collisions {
+		s.emit(GemsCollidedEvent{*collision})
+	}
+	for _, score := range result.Scores {
+		s.emit(GemScoredEvent{*score})
+	}
+
 	// draw tile and add to hand if there tiles left in the deck
 	if t, err = s.deck.Draw(); err == nil {
 		s.hands[team].Add(*t)
+		s.emit(TileDrawnEvent{Team: team})
 	}
 
-	// change turn
-	for idx, team := range s.teams {
-		if team == s.turn {
-			s.turn = s.teams[(idx+1)%len(s.teams)]
+	// change turn skipping teams that cannot place a tile
+	stuck := true
+	for range s.teams {
+		s.nextTurn()
+		if s.round >= s.roundsUntilEnd || s.board.gemsInPlay() <= 0 || s.canPlaceTile() {
+			stuck = false
 			break
 		}
-	}
-
-	// inc round counter
-	if s.turn == s.teams[0] {
-		s.round++
+		s.emit(TurnSkippedEvent{Team: s.turn})
 	}
 
 	// check if the game is over and set winners if so
-	if s.round >= s.roundsUntilEnd || s.board.gemsInPlay() <= 0 {
+	if stuck || s.round >= s.roundsUntilEnd || s.board.gemsInPlay() <= 0 {
 		winners := make([]string, 0)
 		maxPoints := 0
-		for team, points := range s.points {
+		for _, team := range s.teams {
+			points := s.points[team]
 			if points == maxPoints {
 				winners = append(winners, team)
 			} else if points > maxPoints {
@@ -203,9 +262,45 @@ func (s *state) placeTile(team, paths string, row, col int) error {
 			}
 		}
 		s.winners = winners
+		s.emit(GameOverEvent{Winners: winners, Points: copyCounts(s.points)})
 	}
 
-	return nil
+	return result, nil
+}
+
+// nextTurn passes the turn to the next team advancing the round when back to the first team
+func (s *state) nextTurn() {
+	for idx, team := range s.teams {
+		if team == s.turn {
+			s.turn = s.teams[(idx+1)%len(s.teams)]
+			s.hash ^= zobristTurns[idx] ^ zobristTurns[(idx+1)%len(s.teams)]
+			break
+		}
+	}
+	s.emit(TurnChangedEvent{Turn: s.turn})
+
+	// inc round counter
+	if s.turn == s.teams[0] {
+		s.round++
+		s.emit(RoundAdvancedEvent{Round: s.round})
+	}
+}
+
+// canPlaceTile returns whether the team to play has a tile it can place anywhere
+// NOTE - stops at the first legal placement so is much cheaper than legalMoves
+func (s *state) canPlaceTile() bool {
+	for _, t := range s.hands[s.turn].GetItems() {
+		rotated := &tile{Paths: t.Paths}
+		for i := 0; i < 6; i++ {
+			for cell := range s.board.tiles {
+				if s.board.tiles[cell].Paths == "" && s.board.canPlace(rotated, cellRows[cell], cellColumns[cell]) == nil {
+					return true
+				}
+			}
+			rotated.RotateClockwise()
+		}
+	}
+	return false
 }
 
 func (s *state) setWinners(winners []string) error {
@@ -218,6 +313,7 @@ func (s *state) setWinners(winners []string) error {
 		}
 	}
 	s.winners = winners
+	s.emit(GameOverEvent{Winners: winners, Points: copyCounts(s.points)})
 	return nil
 }
 
@@ -226,41 +322,100 @@ func (s *state) targets(team ...string) []*bg.BoardGameAction {
 	if len(s.winners) > 0 {
 		return targets
 	}
-	// rotate tile actions
-	if len(team) == 0 || len(team) == 1 {
-		for t, hand := range s.hands {
-			for _, tile := range hand.GetItems() {
-				targets = append(targets, &bg.BoardGameAction{
-					Team:       t,
-					ActionType: ActionRotateTileClockwise,
-					MoreDetails: RotateTileActionDetails{
-						Tile: tile.Paths,
-					},
-				})
-			}
+	// rotate tile actions only for the hands team can see
+	for _, t := range s.teams {
+		if len(team) == 1 && team[0] != t {
+			continue
+		}
+		for _, tile := range s.hands[t].GetItems() {
+			targets = append(targets, &bg.BoardGameAction{
+				Team:       t,
+				ActionType: ActionRotateTileClockwise,
+				MoreDetails: RotateTileActionDetails{
+					Tile: tile.Paths,
+				},
+			})
 		}
 	}
 	// place tile actions
 	if len(team) == 0 || (len(team) == 1 && team[0] == s.turn) {
-		for r, row := range s.board.Tiles {
-			for c, t := range row {
-				if t == nil {
-					for _, tile := range s.hands[s.turn].GetItems() {
-						targets = append(targets, &bg.BoardGameAction{
-							Team:       s.turn,
-							ActionType: ActionPlaceTile,
-							MoreDetails: PlaceTileActionDetails{
-								Tile:   tile.Paths,
-								Row:    r,
-								Column: c,
-							},
-						})
-					}
+		targets = append(targets, s.legalMoves()...)
+	}
+	return targets
+}
+
+// clone returns a deep copy of the state that shares no data with the original
+func (s *state) clone() *state {
+	hands := make(map[string]*cl.Collection[tile])
+	for team, hand := range s.hands {
+		hands[team] = hand.Clone()
+	}
+	return &state{
+		turn:           s.turn,
+		teams:          append(make([]string, 0), s.teams...),
+		winners:        append(make([]string, 0), s.winners...),
+		board:          s.board.clone(),
+		deck:           s.deck.Clone(),
+		hands:          hands,
+		variant:        s.variant,
+		points:         copyCounts(s.points),
+		gemsCount:      copyCounts(s.gemsCount),
+		round:          s.round,
+		roundsUntilEnd: s.roundsUntilEnd,
+		placed:         s.placed,
+		results:        append(make([]*PlaceTileResult, 0), s.results...),
+		hash:           s.hash,
+	}
+}
+
+// legalMoves returns a place tile action for every distinct tile, rotation and location the board accepts for the current team
+func (s *state) legalMoves() []*bg.BoardGameAction {
+	rotations := make([]*tile, 0)
+	for _, t := range s.hands[s.turn].GetItems() {
+		for _, rotation := range t.rotations() {
+			// skip orientations already covered by an identical tile in hand
+			duplicate := false
+			for _, r := range rotations {
+				if r.samePaths(rotation) {
+					duplicate = true
+					break
 				}
 			}
+			if !duplicate {
+				rotations = append(rotations, rotation)
+			}
 		}
 	}
-	return targets
+	empty := 0
+	for cell := range s.board.tiles {
+		if s.board.tiles[cell].Paths == "" {
+			empty++
+		}
+	}
+	// allocate every action at once as there can be several hundred moves
+	actions := make([]bg.BoardGameAction, 0, empty*len(rotations))
+	moves := make([]*bg.BoardGameAction, 0, empty*len(rotations))
+	for cell := range s.board.tiles {
+		if s.board.tiles[cell].Paths != "" {
+			continue
+		}
+		r, c := cellRows[cell], cellColumns[cell]
+		for _, rotation := range rotations {
+			if s.board.canPlace(rotation, r, c) == nil {
+				actions = append(actions, bg.BoardGameAction{
+					Team:       s.turn,
+					ActionType: ActionPlaceTile,
+					MoreDetails: PlaceTileActionDetails{
+						Tile:   rotation.Paths,
+						Row:    r,
+						Column: c,
+					},
+				})
+				moves = append(moves, &actions[len(actions)-1])
+			}
+		}
+	}
+	return moves
 }
 
 func (s *state) message() string {
@@ -0,0 +1,326 @@
+package go_indigo
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	cl "github.com/quibbble/go-boardgame/pkg/collection"
+)
+
+// placeFromHand gives the team to play a hand of only the tile and places it
+func placeFromHand(t *testing.T, s *state, paths string, row, col int) *PlaceTileResult {
+	hand := cl.NewCollection[tile](0)
+	hand.Add(tile{Paths: paths})
+	s.hands[s.turn] = hand
+	result, err := s.placeTile(s.turn, paths, row, col)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func Test_Rotations(t *testing.T) {
+	tests := map[string]int{
+		A + D + B + F + C + E: 3,
+		A + F + B + C + D + E: 2,
+		B + E + C + F + D + A: 1,
+		A + F + B + E + C + D: 3,
+		A + B + C + E + D + F: 6,
+	}
+	for paths, expected := range tests {
+		if got := len((&tile{Paths: paths}).rotations()); got != expected {
+			t.Errorf("%s has %d distinct rotations but expected %d", paths, got, expected)
+		}
+	}
+}
+
+func Test_LegalMoves(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 11, Variant: VariantLargeHands},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		playPlaceTile(t, game)
+	}
+	moves := game.state.legalMoves()
+	for idx, move := range moves {
+		details := move.MoreDetails.(PlaceTileActionDetails)
+		for _, other := range moves[idx+1:] {
+			o := other.MoreDetails.(PlaceTileActionDetails)
+			if details.Row == o.Row && details.Column == o.Column &&
+				(&tile{Paths: details.Tile}).samePaths(&tile{Paths: o.Tile}) {
+				t.Fatalf("duplicate move %v", details)
+			}
+		}
+		if err := game.Clone().Do(move); err != nil {
+			t.Fatalf("legal move %v rejected: %s", details, err)
+		}
+	}
+
+	// the moves are exactly the placements Do accepts for any rotation of any tile in hand
+	key := func(paths string, row, col int) string {
+		destinations := []byte{byte('0' + row), byte('0' + col)}
+		for _, edge := range []string{A, B, C, D, E, F} {
+			destination, _ := (&tile{Paths: paths}).GetDestination(edge)
+			destinations = append(destinations, destination...)
+		}
+		return string(destinations)
+	}
+	generated := make(map[string]bool)
+	for _, move := range moves {
+		details := move.MoreDetails.(PlaceTileActionDetails)
+		generated[key(details.Tile, details.Row, details.Column)] = true
+		for _, gateway := range game.state.board.gateways {
+			for _, location := range gateway.Locations {
+				destination, _ := (&tile{Paths: details.Tile}).GetDestination(gateway.Edges[0:1])
+				if location[0] == details.Row && location[1] == details.Column && destination == gateway.Edges[1:2] {
+					t.Fatalf("move %v blocks gateway %s", details, gateway.Edges)
+				}
+			}
+		}
+	}
+	accepted := make(map[string]bool)
+	for r, row := range game.state.board.rows() {
+		for c := range row {
+			for _, hand := range game.state.hands[game.state.turn].GetItems() {
+				rotation := &tile{Paths: hand.Paths}
+				for i := 0; i < 6; i++ {
+					rotation.RotateClockwise()
+					if game.Clone().Do(&bg.BoardGameAction{
+						Team:        game.state.turn,
+						ActionType:  ActionPlaceTile,
+						MoreDetails: PlaceTileActionDetails{Tile: rotation.Paths, Row: r, Column: c},
+					}) == nil {
+						accepted[key(rotation.Paths, r, c)] = true
+					}
+				}
+			}
+		}
+	}
+	if !reflect.DeepEqual(generated, accepted) {
+		t.Fatalf("generated %d distinct moves but Do accepts %d placements", len(generated), len(accepted))
+	}
+}
+
+func Test_SharedGatewayScoring(t *testing.T) {
+	tests := []struct {
+		teams    []string
+		expected map[string]int
+	}{
+		{[]string{"red", "blue"}, map[string]int{"red": 0, "blue": 1}},
+		{[]string{"red", "blue", "green"}, map[string]int{"red": 1, "blue": 1, "green": 0}},
+		{[]string{"red", "blue", "green", "yellow"}, map[string]int{"red": 0, "blue": 1, "green": 1, "yellow": 0}},
+	}
+	for _, test := range tests {
+		s, err := newState(test.teams, 1, VariantClassic, 999)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the amber gem on the right treasure tile is moved up to the B C gateway
+		placeFromHand(t, s, A+F+B+C+D+E, 4, 7)
+		result := placeFromHand(t, s, A+B+C+E+D+F, 3, 7)
+		if len(result.Scores) != 1 || result.Scores[0].Edges != B+C || result.Scores[0].Points != 1 {
+			t.Fatalf("%d teams scored %+v but expected the amber gem at the B C gateway", len(test.teams), result.Scores)
+		}
+		if !reflect.DeepEqual(s.points, test.expected) || !reflect.DeepEqual(s.gemsCount, test.expected) || !reflect.DeepEqual(result.Points, test.expected) {
+			t.Errorf("%d teams have points %v and gems %v but expected %v", len(test.teams), s.points, s.gemsCount, test.expected)
+		}
+	}
+}
+
+func Test_TieBreak(t *testing.T) {
+	tests := []struct {
+		name      string
+		points    map[string]int
+		gemsCount map[string]int
+		expected  []string
+	}{
+		{"most points", map[string]int{"a": 4, "b": 3, "c": 3}, map[string]int{"a": 1, "b": 3, "c": 3}, []string{"a"}},
+		{"tie broken by gems", map[string]int{"a": 3, "b": 3, "c": 1}, map[string]int{"a": 2, "b": 1, "c": 1}, []string{"a"}},
+		{"tie between most gems", map[string]int{"a": 3, "b": 3, "c": 3}, map[string]int{"a": 1, "b": 2, "c": 2}, []string{"b", "c"}},
+		{"gems only count when tied", map[string]int{"a": 2, "b": 5, "c": 5}, map[string]int{"a": 4, "b": 2, "c": 2}, []string{"b", "c"}},
+		{"no points", map[string]int{"a": 0, "b": 0, "c": 0}, map[string]int{"a": 0, "b": 0, "c": 0}, []string{"a", "b", "c"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := newState([]string{"a", "b", "c"}, 1, VariantClassic, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.points, s.gemsCount = test.points, test.gemsCount
+			// tiles placed away from any gem so the game ends after one round without scoring
+			placeFromHand(t, s, A+D+B+F+C+E, 2, 2)
+			placeFromHand(t, s, A+D+B+F+C+E, 6, 2)
+			if len(s.winners) > 0 {
+				t.Fatal("game ended before the round was over")
+			}
+			placeFromHand(t, s, A+D+B+F+C+E, 2, 4)
+			if !reflect.DeepEqual(s.winners, test.expected) {
+				t.Errorf("winners %v but expected %v", s.winners, test.expected)
+			}
+		})
+	}
+}
+
+func Test_GemStatus(t *testing.T) {
+	type gemJSON struct {
+		ID      int
+		Status  string
+		Ply     int
+		Gateway *struct {
+			Edges string
+			Teams []string
+		}
+	}
+	gems := func(game *Indigo) []gemJSON {
+		snapshot, err := game.GetSnapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded struct {
+			MoreData struct{ Board struct{ Gems []gemJSON } }
+		}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		return decoded.MoreData.Board.Gems
+	}
+
+	game, err := NewIndigo(&bg.BoardGameOptions{Teams: []string{"red", "blue", "green"}, MoreOptions: IndigoMoreOptions{Seed: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, gem := range gems(game) {
+		if gem.ID != idx || gem.Status != GemInPlay || gem.Ply != 0 || gem.Gateway != nil {
+			t.Fatalf("gem %d is %+v at the start of the game", idx, gem)
+		}
+	}
+
+	// two gems from the center collide on the third tile then an amber gem scores on the fifth
+	placeFromHand(t, game.state, A+B+C+E+D+F, 3, 4)
+	placeFromHand(t, game.state, A+D+B+F+C+E, 4, 5)
+	placeFromHand(t, game.state, F+E+A+B+C+D, 3, 5)
+	placeFromHand(t, game.state, A+F+B+C+D+E, 4, 7)
+	placeFromHand(t, game.state, A+B+C+E+D+F, 3, 7)
+	for idx, gem := range gems(game) {
+		switch idx {
+		case 6, 7:
+			if gem.Status != GemCollided || gem.Ply != 3 || gem.Gateway != nil {
+				t.Errorf("gem %d is %+v but expected it to collide on ply 3", idx, gem)
+			}
+		case 2:
+			if gem.Status != GemScored || gem.Ply != 5 || gem.Gateway == nil || gem.Gateway.Edges != B+C ||
+				!reflect.DeepEqual(gem.Gateway.Teams, []string{"red", "blue"}) {
+				t.Errorf("gem %d is %+v but expected it to score at the B C gateway on ply 5", idx, gem)
+			}
+		default:
+			if gem.Status != GemInPlay || gem.Ply != 0 {
+				t.Errorf("gem %d is %+v but expected it in play", idx, gem)
+			}
+		}
+	}
+}
+
+func Test_SkipStuckTeams(t *testing.T) {
+	s, err := newState([]string{"a", "b", "c"}, 1, VariantClassic, 999)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make([]Event, 0)
+	s.notify = func(event Event) { events = append(events, event) }
+	skipped := func() []string {
+		teams := make([]string, 0)
+		for _, event := range events {
+			if e, ok := event.(TurnSkippedEvent); ok {
+				teams = append(teams, e.Team)
+			}
+		}
+		return teams
+	}
+
+	// with the deck empty b has no tile so is skipped
+	s.deck = cl.NewCollection[tile](0)
+	s.hands["b"] = cl.NewCollection[tile](0)
+	placeFromHand(t, s, A+D+B+F+C+E, 2, 2)
+	if s.turn != "c" || len(s.winners) > 0 || !reflect.DeepEqual(skipped(), []string{"b"}) {
+		t.Fatalf("turn %s, winners %v and skipped %v after a placed the tile", s.turn, s.winners, skipped())
+	}
+
+	// once c places its last tile nobody can move so the game ends even though gems are in play
+	events = events[:0]
+	placeFromHand(t, s, A+D+B+F+C+E, 6, 2)
+	if !reflect.DeepEqual(skipped(), []string{"a", "b", "c"}) {
+		t.Errorf("skipped %v after c placed the tile", skipped())
+	}
+	turns := make([]Event, 0)
+	for _, event := range events {
+		switch event.(type) {
+		case TurnChangedEvent, TurnSkippedEvent, GameOverEvent:
+			turns = append(turns, event)
+		}
+	}
+	expected := []Event{
+		TurnChangedEvent{Turn: "a"}, TurnSkippedEvent{Team: "a"},
+		TurnChangedEvent{Turn: "b"}, TurnSkippedEvent{Team: "b"},
+		TurnChangedEvent{Turn: "c"}, TurnSkippedEvent{Team: "c"},
+		GameOverEvent{Winners: s.winners, Points: s.points},
+	}
+	if !reflect.DeepEqual(turns, expected) {
+		t.Errorf("turn events %v but expected every team to be skipped once before the game ends", turns)
+	}
+	if s.board.gemsInPlay() == 0 || len(s.winners) != 3 {
+		t.Errorf("winners %v with %d gems in play", s.winners, s.board.gemsInPlay())
+	}
+	if _, ok := events[len(events)-1].(GameOverEvent); !ok {
+		t.Errorf("last event %T but expected the game to be over", events[len(events)-1])
+	}
+}
+
+func Test_LongGames(t *testing.T) {
+	for seed := int64(0); seed < 40; seed++ {
+		teams := []string{"red", "blue", "green", "yellow"}[:2+seed%3]
+		variant := variants[seed%2]
+		game, err := NewIndigo(&bg.BoardGameOptions{Teams: teams, MoreOptions: IndigoMoreOptions{Seed: seed, Variant: variant}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := rand.New(rand.NewSource(seed))
+		placed := 0
+		for ; len(game.state.winners) == 0; placed++ {
+			if placed == 54 {
+				t.Fatalf("%s game with seed %d and %d teams did not end once every tile was placed", variant, seed, len(teams))
+			}
+			moves := game.state.legalMoves()
+			if len(moves) == 0 {
+				t.Fatalf("%s cannot move in %s game with seed %d and %d teams", game.state.turn, variant, seed, len(teams))
+			}
+			if err := game.Do(moves[r.Intn(len(moves))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTargets(b *testing.B) {
+	game, err := NewIndigo(benchmarkOptions)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 15; i++ {
+		playPlaceTile(b, game)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.state.targets()
+	}
+}
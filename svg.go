@@ -0,0 +1,151 @@
+package go_indigo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	hexSize   = 40.0 // the distance from the center of a tile to its corners
+	svgMargin = 20.0
+)
+
+var (
+	// edgeToSide is the index of the side of a pointy top hexagon each edge is on going clockwise from the upper left
+	edgeToSide = map[byte]int{'A': 0, 'B': 1, 'C': 2, 'D': 3, 'E': 4, 'F': 5}
+
+	colorToFill = map[string]string{
+		Amber:    "#ffbf00",
+		Emerald:  "#2e8b57",
+		Sapphire: "#0f52ba",
+	}
+
+	// teamColors are used for teams whose names are not already colors
+	teamColors = []string{"#d62728", "#1f77b4", "#2ca02c", "#9467bd"}
+
+	// namedColors are team names that are drawn in their own color
+	namedColors = []string{"red", "blue", "green", "yellow", "orange", "purple", "pink", "black", "white", "gray", "brown", "cyan", "magenta"}
+)
+
+// RenderSVG draws the board, gems in play, gateways and points in the snapshot as an SVG image
+func RenderSVG(data *IndigoSnapshotData) string {
+	width := math.Sqrt(3) * hexSize
+	imgWidth := 2*svgMargin + maxColumns*width
+	boardHeight := 2*svgMargin + (rows-1)*1.5*hexSize + 2*hexSize
+	teams := sortedKeys(data.Points)
+	imgHeight := boardHeight + float64(len(teams))*20
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n", imgWidth, imgHeight, imgWidth, imgHeight))
+	sb.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+
+	for r, row := range data.Board.rows() {
+		for c, t := range row {
+			cx, cy := hexCenter(r, c, len(row))
+			fill := "#f4f4f4"
+			if t != nil && t.Treasure {
+				fill = "#d9d2c5"
+			} else if t != nil {
+				fill = "#f5deb3"
+			}
+			sb.WriteString(fmt.Sprintf(`<polygon points="%s" fill="%s" stroke="#999999" stroke-width="1"/>`+"\n", hexPoints(cx, cy), fill))
+			// treasure tiles have no paths only the edges gems leave by
+			if t == nil || t.Treasure {
+				continue
+			}
+			for i := 0; i+1 < len(t.Paths); i += 2 {
+				x1, y1 := sideMidpoint(cx, cy, edgeToSide[t.Paths[i]], 1)
+				x2, y2 := sideMidpoint(cx, cy, edgeToSide[t.Paths[i+1]], 1)
+				sb.WriteString(fmt.Sprintf(`<path d="M %.1f %.1f Q %.1f %.1f %.1f %.1f" fill="none" stroke="#555555" stroke-width="3"/>`+"\n", x1, y1, cx, cy, x2, y2))
+			}
+		}
+	}
+
+	for _, gateway := range data.Board.gateways {
+		for _, location := range gateway.Locations {
+			cx, cy := hexCenter(location[0], location[1], rowLength(location[0]))
+			for i := 0; i < len(gateway.Edges); i++ {
+				x1, y1, x2, y2 := sideEnds(cx, cy, edgeToSide[gateway.Edges[i]])
+				// split the side between every owner
+				for o, team := range gateway.Teams {
+					start, end := float64(o)/float64(len(gateway.Teams)), float64(o+1)/float64(len(gateway.Teams))
+					sb.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="6"/>`+"\n",
+						x1+(x2-x1)*start, y1+(y2-y1)*start, x1+(x2-x1)*end, y1+(y2-y1)*end, teamColor(team, teams)))
+				}
+			}
+		}
+	}
+
+	special := 0
+	for _, gem := range data.Board.gems {
+		if gem.Status() != GemInPlay {
+			continue
+		}
+		row, col, edge := gem.location()
+		cx, cy := hexCenter(row, col, rowLength(row))
+		var x, y float64
+		if edge == Special {
+			// arrange the gems on the center tile in a ring
+			angle := float64(special) * math.Pi / 3
+			x, y = cx+0.45*hexSize*math.Cos(angle), cy+0.45*hexSize*math.Sin(angle)
+			special++
+		} else {
+			x, y = sideMidpoint(cx, cy, edgeToSide[edge[0]], 0.7)
+		}
+		sb.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="#333333" stroke-width="1"/>`+"\n", x, y, 0.15*hexSize, colorToFill[gem.Color]))
+	}
+
+	for idx, team := range teams {
+		sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" font-family="sans-serif" font-size="16" fill="%s">%s: %d</text>`+"\n",
+			svgMargin, boardHeight+float64(idx)*20, teamColor(team, teams), escapeXML(team), data.Points[team]))
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// hexCenter returns the center of the tile at (row, col) in a row with the given number of columns
+func hexCenter(row, col, columns int) (float64, float64) {
+	width := math.Sqrt(3) * hexSize
+	x := svgMargin + float64(maxColumns-columns)*width/2 + float64(col)*width + width/2
+	y := svgMargin + hexSize + float64(row)*1.5*hexSize
+	return x, y
+}
+
+// hexCorner returns the corner at the start of side going clockwise
+func hexCorner(cx, cy float64, side int) (float64, float64) {
+	angle := float64(60*side-150) * math.Pi / 180
+	return cx + hexSize*math.Cos(angle), cy + hexSize*math.Sin(angle)
+}
+
+func hexPoints(cx, cy float64) string {
+	points := make([]string, 0)
+	for side := 0; side < 6; side++ {
+		x, y := hexCorner(cx, cy, side)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	return strings.Join(points, " ")
+}
+
+func sideEnds(cx, cy float64, side int) (float64, float64, float64, float64) {
+	x1, y1 := hexCorner(cx, cy, side)
+	x2, y2 := hexCorner(cx, cy, (side+1)%6)
+	return x1, y1, x2, y2
+}
+
+// sideMidpoint returns the point scale of the way from the center to the middle of side
+func sideMidpoint(cx, cy float64, side int, scale float64) (float64, float64) {
+	x1, y1, x2, y2 := sideEnds(cx, cy, side)
+	return cx + ((x1+x2)/2-cx)*scale, cy + ((y1+y2)/2-cy)*scale
+}
+
+func teamColor(team string, teams []string) string {
+	if contains(namedColors, strings.ToLower(team)) {
+		return strings.ToLower(team)
+	}
+	return teamColors[indexOf(teams, team)%len(teamColors)]
+}
+
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
@@ -0,0 +1,50 @@
+package go_indigo
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+func Test_RenderSVG(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue", "Team & Co"},
+		MoreOptions: IndigoMoreOptions{Seed: 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 15; i++ {
+		playPlaceTile(t, game)
+	}
+	snapshot, _ := game.GetSnapshot()
+	data := snapshot.MoreData.(IndigoSnapshotData)
+	svg := RenderSVG(&data)
+
+	circles, paths := 0, 0
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid svg: %s", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "circle" {
+			circles++
+		} else if ok && start.Name.Local == "path" {
+			paths++
+		}
+	}
+	if circles != game.state.board.gemsInPlay() {
+		t.Fatalf("expected %d gems drawn but got %d", game.state.board.gemsInPlay(), circles)
+	}
+
+	// only placed tiles have paths drawn as treasure tiles have none
+	if paths != 15*3 {
+		t.Fatalf("expected %d paths drawn but got %d", 15*3, paths)
+	}
+}
@@ -0,0 +1,129 @@
+package go_indigo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	cellWidth  = 10 // the number of characters used to draw a tile
+	cellStride = 12 // the number of characters between the start of adjacent tiles
+)
+
+// colorToMarker is the character used to draw a gem of each color
+var colorToMarker = map[string]byte{
+	Amber:    'a',
+	Emerald:  'e',
+	Sapphire: 's',
+}
+
+// RenderText draws the board followed by the round, points and hands in the snapshot
+func RenderText(data *IndigoSnapshotData) string {
+	var sb strings.Builder
+	sb.WriteString(data.Board.String())
+	sb.WriteString(fmt.Sprintf("\nround %d of %d\n", data.Round+1, data.RoundsUntilEnd))
+	for _, team := range sortedKeys(data.Points) {
+		sb.WriteString(fmt.Sprintf("%s: %d points", team, data.Points[team]))
+		if hand, ok := data.Hands[team]; ok {
+			paths := make([]string, 0)
+			for _, t := range hand {
+				paths = append(paths, t.Paths)
+			}
+			sb.WriteString(fmt.Sprintf(", hand %s", strings.Join(paths, " ")))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// String draws the board with three lines per row of tiles followed by the gateways and who owns them
+//
+// Each tile is drawn as a hexagon showing its paths with the gems in play replacing the edge they sit on
+//
+//	  /    \
+//	| ABCDEF |
+//	  \    /
+//
+// Treasure tiles are drawn between stars and the center tile shows how many gems of each color remain on it
+func (b *board) String() string {
+	var sb strings.Builder
+	for r, row := range b.rows() {
+		lines := [3][]byte{}
+		indent := (maxColumns - len(row)) * cellStride / 2
+		for l := range lines {
+			lines[l] = []byte(strings.Repeat(" ", indent+maxColumns*cellStride))
+		}
+		for c, t := range row {
+			x := indent + c*cellStride
+			copy(lines[1][x+2:], b.cellText(t))
+			lines[0][x+2] = b.gemMarker(r, c, A, '/')
+			lines[0][x+7] = b.gemMarker(r, c, B, '\\')
+			lines[1][x] = b.gemMarker(r, c, F, '|')
+			lines[1][x+cellWidth-1] = b.gemMarker(r, c, C, '|')
+			lines[2][x+2] = b.gemMarker(r, c, E, '\\')
+			lines[2][x+7] = b.gemMarker(r, c, D, '/')
+		}
+		for l, line := range lines {
+			label := "   "
+			if l == 1 {
+				label = fmt.Sprintf("%-3d", r)
+			}
+			sb.WriteString(strings.TrimRight(label+string(line), " ") + "\n")
+		}
+	}
+	for _, gateway := range b.gateways {
+		locations := make([]string, 0)
+		for _, location := range gateway.Locations {
+			locations = append(locations, fmt.Sprintf("(%d, %d)", location[0], location[1]))
+		}
+		sb.WriteString(fmt.Sprintf("gateway %s at %s: %s\n", gateway.Edges, strings.Join(locations, " "), strings.Join(gateway.Teams, ", ")))
+	}
+	return sb.String()
+}
+
+// cellText returns the six characters drawn in the middle of a tile
+func (b *board) cellText(t *tile) string {
+	switch {
+	case t == nil:
+		return "      "
+	case t.Paths == Special:
+		counts := make(map[string]int)
+		for _, gem := range b.gems {
+			if gem.edge == specialEdge && gem.Status() == GemInPlay {
+				counts[gem.Color]++
+			}
+		}
+		text := "*"
+		for _, color := range []string{Amber, Emerald, Sapphire} {
+			if counts[color] > 0 {
+				text += fmt.Sprintf("%d%c", counts[color], colorToMarker[color])
+			}
+		}
+		return fmt.Sprintf("%-6s", text+"*")
+	case t.Treasure:
+		return fmt.Sprintf("%-6s", "*"+t.Paths+"*")
+	default:
+		return t.Paths
+	}
+}
+
+// gemMarker returns the character for the gem in play at (row, col, edge) or border if there is none
+func (b *board) gemMarker(row, col int, edge string, border byte) byte {
+	cell, e := cellIndex(row, col), edgeIndex[edge[0]]
+	for _, gem := range b.gems {
+		if gem.cell == cell && gem.edge == e && gem.Status() == GemInPlay {
+			return colorToMarker[gem.Color]
+		}
+	}
+	return border
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
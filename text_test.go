@@ -0,0 +1,20 @@
+package go_indigo
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_BoardString(t *testing.T) {
+	b := newBoard([]string{"red", "blue"})
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != rows*3+len(b.gateways) {
+		t.Fatalf("expected %d lines but got %d", rows*3+len(b.gateways), len(lines))
+	}
+	if !strings.Contains(lines[4*3+1], "*5e1s*") {
+		t.Fatalf("center tile not drawn with its gems: %s", lines[4*3+1])
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[4*3+1]), "4  | *BDC*  a") {
+		t.Fatalf("amber gem not drawn on the C edge of (4, 0): %s", lines[4*3+1])
+	}
+}
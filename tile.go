@@ -13,6 +13,9 @@ const (
 	Special = "S" // Special edge represents all edges on the central treasure tile
 )
 
+// rotateClockwise maps each edge character to the edge it becomes after one clockwise rotation
+var rotateClockwise = [256]byte{'A': 'B', 'B': 'C', 'C': 'D', 'D': 'E', 'E': 'F', 'F': 'A'}
+
 /*
 tile representation
 
@@ -50,32 +53,121 @@ func newTile(paths string) (*tile, error) {
 }
 
 func (t *tile) GetDestination(startingEdge string) (string, error) {
-	for idx, char := range t.Paths {
-		if string(char) == startingEdge && idx%2 == 0 {
-			return string(t.Paths[idx+1]), nil
-		} else if string(char) == startingEdge && idx%2 == 1 {
-			return string(t.Paths[idx-1]), nil
+	if len(startingEdge) == 1 {
+		for idx := 0; idx < len(t.Paths); idx++ {
+			if t.Paths[idx] != startingEdge[0] {
+				continue
+			}
+			if idx%2 == 0 && idx+1 < len(t.Paths) {
+				return t.Paths[idx+1 : idx+2], nil
+			} else if idx%2 == 1 {
+				return t.Paths[idx-1 : idx], nil
+			}
+			break
 		}
 	}
 	return "", fmt.Errorf("no destination found for tile %s with starting edge %s", t.Paths, startingEdge)
 }
 
 func (t *tile) RotateClockwise() {
-	transform := map[string]string{A: B, B: C, C: D, D: E, E: F, F: A}
-	transformed := ""
-	for _, char := range t.Paths {
-		transformed += transform[string(char)]
+	var buf [6]byte
+	transformed := buf[:0]
+	for i := 0; i < len(t.Paths); i++ {
+		transformed = append(transformed, rotateClockwise[t.Paths[i]])
 	}
-	t.Paths = transformed
+	t.Paths = string(transformed)
 }
 
 func (t *tile) equals(t2 *tile) bool {
-	copied, _ := newTile(t.Paths)
-	for i := 0; i < 6; i++ {
-		if copied.Paths == t2.Paths {
+	if len(t.Paths) != len(t2.Paths) {
+		return false
+	}
+	for r := 0; r < 6; r++ {
+		rotated := true
+		for i := 0; i < len(t.Paths) && rotated; i++ {
+			rotated = rotateClockwise[t.Paths[i]] != 0 && allEdges[(edgeIndex[t.Paths[i]]+r)%6][0] == t2.Paths[i]
+		}
+		if rotated {
 			return true
 		}
-		copied.RotateClockwise()
 	}
 	return false
 }
+
+// uniqueIndex returns the index of the tile's paths in uniquePaths ignoring rotation or -1 if not found
+func (t *tile) uniqueIndex() int {
+	for idx, paths := range uniquePaths {
+		if t.equals(&tile{Paths: paths}) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// samePaths returns whether both tiles connect the same edges regardless of how their paths are written
+func (t *tile) samePaths(t2 *tile) bool {
+	if len(t.Paths) != len(t2.Paths) {
+		return false
+	}
+	for _, edge := range t.Paths {
+		d1, err1 := t.GetDestination(string(edge))
+		d2, err2 := t2.GetDestination(string(edge))
+		if err1 != nil || err2 != nil || d1 != d2 {
+			return false
+		}
+	}
+	return true
+}
+
+// rotations returns every distinct orientation of the tile starting with its current one
+// NOTE - symmetric tiles such as ADBFCE have fewer than six distinct orientations
+func (t *tile) rotations() []*tile {
+	rotations := make([]*tile, 0)
+	rotated := &tile{Paths: t.Paths}
+	for i := 0; i < 6; i++ {
+		distinct := true
+		for _, r := range rotations {
+			if r.samePaths(rotated) {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			rotations = append(rotations, &tile{Paths: rotated.Paths})
+		}
+		rotated.RotateClockwise()
+	}
+	return rotations
+}
+
+// numTileIDs is the number of distinct tile ids i.e. every unique tile in each of its six rotations
+const numTileIDs = 30
+
+// id returns a number identifying the tile's paths and rotation or -1 if the tile is not a valid non treasure tile
+// NOTE - rotations of symmetric tiles that connect the same edges share the lowest id
+func (t *tile) id() int {
+	idx := t.uniqueIndex()
+	if idx < 0 || t.Treasure {
+		return -1
+	}
+	rotated := &tile{Paths: uniquePaths[idx]}
+	for r := 0; r < 6; r++ {
+		if rotated.samePaths(t) {
+			return idx*6 + r
+		}
+		rotated.RotateClockwise()
+	}
+	return -1
+}
+
+// tileFromID returns the tile identified by id
+func tileFromID(id int) (*tile, error) {
+	if id < 0 || id >= numTileIDs {
+		return nil, fmt.Errorf("invalid tile id %d", id)
+	}
+	t := &tile{Paths: uniquePaths[id/6]}
+	for r := 0; r < id%6; r++ {
+		t.RotateClockwise()
+	}
+	return t, nil
+}
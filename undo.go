@@ -0,0 +1,117 @@
+package go_indigo
+
+import (
+	"fmt"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+)
+
+// checkpoint is a saved copy of the game used to undo and redo actions
+// NOTE - checkpoints are never modified once saved so restoring one always works on a copy
+type checkpoint struct {
+	state   *state
+	actions []*bg.BoardGameAction
+}
+
+// save stores the game as it was before the latest tracked action and clears anything that could be redone
+func (i *Indigo) save(before *state, actions []*bg.BoardGameAction) {
+	i.undos = append(i.undos, &checkpoint{
+		state:   before,
+		actions: append(make([]*bg.BoardGameAction, 0), actions...),
+	})
+	i.redos = make([]*checkpoint, 0)
+}
+
+// undoneBy checks that team made each of the last count tracked actions so only it can take them back
+func (i *Indigo) undoneBy(team string, count int) error {
+	if count <= 0 || count > len(i.undos) {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("cannot undo %d actions when %d can be undone", count, len(i.undos)),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
+	for _, undo := range i.undos[len(i.undos)-count:] {
+		// the history before each checkpoint is a prefix of the current history so the next action is the undone one
+		if undone := i.actions[len(undo.actions)]; undone.Team != team {
+			return &bgerr.Error{
+				Err:    fmt.Errorf("%s cannot undo an action made by %s", team, undone.Team),
+				Status: bgerr.StatusWrongTurn,
+			}
+		}
+	}
+	return nil
+}
+
+// redoneBy checks that team made each of the next count undone actions so only it can redo them
+func (i *Indigo) redoneBy(team string, count int) error {
+	if count <= 0 || count > len(i.redos) {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("cannot redo %d actions when %d can be redone", count, len(i.redos)),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
+	actions := i.actions
+	for idx := len(i.redos) - 1; idx >= len(i.redos)-count; idx-- {
+		if redone := i.redos[idx].actions[len(actions)]; redone.Team != team {
+			return &bgerr.Error{
+				Err:    fmt.Errorf("%s cannot redo an action made by %s", team, redone.Team),
+				Status: bgerr.StatusWrongTurn,
+			}
+		}
+		actions = i.redos[idx].actions
+	}
+	return nil
+}
+
+// Undo reverts the last count tracked actions restoring the game exactly as it was before them
+// NOTE - unlike the Undo action this does not check who made the actions or if the game is over so bots can search
+func (i *Indigo) Undo(count int) error {
+	if count <= 0 || count > len(i.undos) {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("cannot undo %d actions when %d can be undone", count, len(i.undos)),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
+	for n := count; n > 0; n-- {
+		last := i.undos[len(i.undos)-1]
+		i.undos = i.undos[:len(i.undos)-1]
+		i.redos = append(i.redos, &checkpoint{
+			state:   i.state,
+			actions: i.actions,
+		})
+		i.state = last.state.clone()
+		if len(i.observers) > 0 {
+			i.state.notify = i.notify
+		}
+		i.actions = append(make([]*bg.BoardGameAction, 0), last.actions...)
+	}
+	i.state.emit(UndoneEvent{Count: count})
+	return nil
+}
+
+// Redo reapplies the last count undone actions restoring the game exactly as it was after them
+// NOTE - unlike the Redo action this does not check who made the actions or if the game is over so bots can search
+func (i *Indigo) Redo(count int) error {
+	if count <= 0 || count > len(i.redos) {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("cannot redo %d actions when %d can be redone", count, len(i.redos)),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
+	for n := count; n > 0; n-- {
+		last := i.redos[len(i.redos)-1]
+		i.redos = i.redos[:len(i.redos)-1]
+		i.undos = append(i.undos, &checkpoint{
+			state:   i.state,
+			actions: i.actions,
+		})
+		i.state = last.state.clone()
+		if len(i.observers) > 0 {
+			i.state.notify = i.notify
+		}
+		i.actions = append(make([]*bg.BoardGameAction, 0), last.actions...)
+	}
+	i.state.emit(RedoneEvent{Count: count})
+	return nil
+}
@@ -0,0 +1,154 @@
+package go_indigo
+
+import (
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+func Test_UndoRedo(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue", "green"},
+		MoreOptions: IndigoMoreOptions{Seed: 123, Variant: VariantLargeHands},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history := []string{dump(t, game)}
+	for i := 0; i < 10; i++ {
+		playPlaceTile(t, game)
+		history = append(history, dump(t, game))
+	}
+
+	if err := game.Do(&bg.BoardGameAction{Team: "red", ActionType: ActionUndo}); err != nil {
+		t.Fatal(err)
+	}
+	if dump(t, game) != history[9] {
+		t.Fatal("undo did not restore the previous game")
+	}
+	if err := game.Undo(4); err != nil {
+		t.Fatal(err)
+	}
+	if dump(t, game) != history[5] {
+		t.Fatal("undo did not restore the game five actions back")
+	}
+	if err := game.Redo(5); err != nil {
+		t.Fatal(err)
+	}
+	if dump(t, game) != history[10] {
+		t.Fatal("redo did not restore the latest game")
+	}
+	if err := game.Redo(1); err == nil {
+		t.Fatal("expected redo to fail with nothing to redo")
+	}
+
+	// a new action clears anything that could have been redone
+	if err := game.Undo(2); err != nil {
+		t.Fatal(err)
+	}
+	playPlaceTile(t, game)
+	if err := game.Redo(1); err == nil {
+		t.Fatal("expected redo to fail after a new action")
+	}
+	if err := game.Undo(9); err != nil {
+		t.Fatal(err)
+	}
+	if dump(t, game) != history[0] {
+		t.Fatal("undo did not restore the initial game")
+	}
+	if err := game.Undo(1); err == nil {
+		t.Fatal("expected undo to fail with nothing to undo")
+	}
+}
+
+func Test_RotateClearsRedo(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playPlaceTile(t, game)
+	if err := game.Undo(1); err != nil {
+		t.Fatal(err)
+	}
+	hand := game.state.hands["red"].GetItems()[0]
+	if err := game.Do(&bg.BoardGameAction{
+		Team:        "red",
+		ActionType:  ActionRotateTileClockwise,
+		MoreDetails: RotateTileActionDetails{Tile: hand.Paths},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rotated := dump(t, game)
+	if err := game.Do(&bg.BoardGameAction{Team: "red", ActionType: ActionRedo}); err == nil {
+		t.Fatal("expected redo to fail after a rotation")
+	}
+	if dump(t, game) != rotated {
+		t.Fatal("redo threw away the rotation")
+	}
+}
+
+func Test_UndoRedoTeam(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playPlaceTile(t, game)
+	playPlaceTile(t, game)
+
+	// blue placed the last tile so only blue can take it back
+	if err := game.Do(&bg.BoardGameAction{Team: "red", ActionType: ActionUndo}); err == nil {
+		t.Fatal("expected red to be unable to undo a tile placed by blue")
+	}
+	if err := game.Do(&bg.BoardGameAction{Team: "blue", ActionType: ActionUndo, MoreDetails: UndoActionDetails{Count: 2}}); err == nil {
+		t.Fatal("expected blue to be unable to undo a tile placed by red")
+	}
+	if err := game.Do(&bg.BoardGameAction{Team: "blue", ActionType: ActionUndo}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Do(&bg.BoardGameAction{Team: "red", ActionType: ActionRedo}); err == nil {
+		t.Fatal("expected red to be unable to redo a tile placed by blue")
+	}
+	if err := game.Do(&bg.BoardGameAction{Team: "blue", ActionType: ActionRedo}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_UndoRedoGameOver(t *testing.T) {
+	game, err := NewIndigo(&bg.BoardGameOptions{
+		Teams:       []string{"red", "blue"},
+		MoreOptions: IndigoMoreOptions{Seed: 5, RoundsUntilEnd: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playPlaceTile(t, game)
+	playPlaceTile(t, game)
+	if len(game.state.winners) == 0 {
+		t.Fatal("expected the game to be over after one round")
+	}
+
+	// the seed is revealed once the game is over so play must not continue from an earlier position
+	for _, team := range game.state.teams {
+		if err := game.Do(&bg.BoardGameAction{Team: team, ActionType: ActionUndo}); err == nil {
+			t.Fatalf("expected %s to be unable to undo once the game is over", team)
+		}
+	}
+
+	// bots still take back the final tile directly when searching
+	if err := game.Undo(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Do(&bg.BoardGameAction{Team: "blue", ActionType: ActionRedo}); err != nil {
+		t.Fatal(err)
+	}
+	if len(game.state.winners) == 0 {
+		t.Fatal("expected redo to end the game again")
+	}
+}
@@ -36,3 +36,11 @@ func duplicates(list []string) bool {
 	}
 	return false
 }
+
+func copyCounts(m map[string]int) map[string]int {
+	n := make(map[string]int, len(m))
+	for k, v := range m {
+		n[k] = v
+	}
+	return n
+}
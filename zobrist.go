@@ -0,0 +1,97 @@
+package go_indigo
+
+import "math/rand"
+
+const (
+	numGemStates = numCells*7 + 1 + numGateways
+)
+
+var (
+	// zobrist keys for every tile in every cell, every state of every gem and every team's turn
+	zobristTiles [numCells][numTileIDs]uint64
+	zobristGems  [numGems][numGemStates]uint64
+	zobristTurns [maxTeams]uint64
+)
+
+func init() {
+	r := rand.New(rand.NewSource(0x1d160))
+	for c := range zobristTiles {
+		for t := range zobristTiles[c] {
+			zobristTiles[c][t] = r.Uint64()
+		}
+	}
+	for g := range zobristGems {
+		for s := range zobristGems[g] {
+			zobristGems[g][s] = r.Uint64()
+		}
+	}
+	for t := range zobristTurns {
+		zobristTurns[t] = r.Uint64()
+	}
+}
+
+// gemState returns a number for where a gem is, whether it collided or which gateway it reached
+func gemState(gem *gem) int {
+	if gem.gateway >= 0 {
+		return numCells*7 + 1 + gem.gateway
+	}
+	if gem.collided {
+		return numCells * 7
+	}
+	return slotIndex(gem.cell, gem.edge)
+}
+
+// gemKey returns the zobrist key for the gem at idx in its current state
+func gemKey(idx int, gem *gem) uint64 {
+	return zobristGems[idx][gemState(gem)]
+}
+
+// updateGemKeys swaps the keys of only the gems that moved or collided during the placement recorded by result
+// NOTE - every changed gem was in play before so its old key comes from the start of its path or where it stopped
+func (s *state) updateGemKeys(result *PlaceTileResult) {
+	before := make(map[int]int)
+	for _, path := range result.Paths {
+		start := path.Steps[0]
+		before[path.Gem] = slotIndex(cellIndex(start.Row, start.Column), edgeIndex[start.Edge[0]])
+	}
+	for _, collision := range result.Collisions {
+		for _, idx := range collision.Gems {
+			// a gem that collided without moving is still where it was
+			if _, ok := before[idx]; !ok {
+				gem := &s.board.gems[idx]
+				before[idx] = slotIndex(gem.cell, gem.edge)
+			}
+		}
+	}
+	for idx, state := range before {
+		s.hash ^= zobristGems[idx][state] ^ gemKey(idx, &s.board.gems[idx])
+	}
+}
+
+// zobrist computes the hash of the board tiles, gem states and team to play from scratch
+// NOTE - hands, the deck and the round are not part of the hash
+func (s *state) zobrist() uint64 {
+	var hash uint64
+	for cell := range s.board.tiles {
+		if t := &s.board.tiles[cell]; t.Paths != "" && !t.Treasure {
+			if id := t.id(); id >= 0 {
+				hash ^= zobristTiles[cell][id]
+			}
+		}
+	}
+	for idx := range s.board.gems {
+		hash ^= gemKey(idx, &s.board.gems[idx])
+	}
+	if idx := indexOf(s.teams, s.turn); idx >= 0 {
+		hash ^= zobristTurns[idx]
+	}
+	return hash
+}
+
+// Hash returns a zobrist hash of the position i.e. the tiles on the board, the state of every gem and the team to play
+// Equal positions have equal hashes so they can be found in O(1) and the hash is updated as tiles are placed
+// NOTE - points follow from the gems so are covered but the hands, deck and round are not so two games with the
+// same hash can differ in the tiles each team holds and a transposition table should also key on the hands it depends on
+func (i *Indigo) Hash() uint64 {
+	return i.state.hash
+}